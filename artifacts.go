@@ -0,0 +1,88 @@
+package ghost
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// artifactEnv names a directory to write the artifacts of failures to.
+const artifactEnv = "GHOST_ARTIFACT_DIR"
+
+// withArtifacts writes the artifacts of a result to files under the artifact
+// directory, if one is set, and returns a copy of the result whose message
+// lists the files.
+//
+// Files are written to a directory for the test, and named after the
+// location of the failure so that each failure in a test has its own files.
+func withArtifacts(result Result, test string) Result {
+	dir := os.Getenv(artifactEnv)
+	if dir == "" || result.Details == nil || len(result.Details.Artifacts) == 0 {
+		return result
+	}
+
+	paths, err := writeArtifacts(
+		filepath.Join(dir, artifactPath(test)),
+		artifactPrefix(result.Details.Location),
+		result.Details.Artifacts,
+	)
+	if err != nil {
+		result.Message += fmt.Sprintf("\n\nfull output could not be written: %v", err)
+		return result
+	}
+
+	result.Message += "\n\nfull output written to:\n\t" + strings.Join(paths, "\n\t")
+	return result
+}
+
+// artifactPath converts the name of a test to a relative path, with a
+// directory for each subtest. Names that could refer to a directory outside
+// of the artifact directory, such as "..", are escaped.
+func artifactPath(test string) string {
+	segments := strings.Split(test, "/")
+	for i, s := range segments {
+		s = strings.NewReplacer(`\`, "%5C", ":", "%3A").Replace(s)
+		if strings.Trim(s, ".") == "" {
+			s = strings.ReplaceAll(s, ".", "%2E")
+		}
+		segments[i] = s
+	}
+
+	return filepath.Join(segments...)
+}
+
+// artifactPrefix names the files for a failure after its location, such as
+// "foo_test.go-12-".
+func artifactPrefix(location Location) string {
+	if location.IsZero() {
+		return ""
+	}
+
+	return filepath.Base(location.File) + "-" + strconv.Itoa(location.Line) + "-"
+}
+
+func writeArtifacts(dir, prefix string, artifacts map[string]string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(artifacts))
+	for name := range artifacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, prefix+name)
+		if err := os.WriteFile(path, []byte(artifacts[name]), 0o600); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
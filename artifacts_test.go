@@ -0,0 +1,130 @@
+package ghost_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+func TestArtifacts(t *testing.T) {
+	t.Run("written", func(t *testing.T) {
+		g := ghost.New(t)
+
+		dir := t.TempDir()
+		t.Setenv("GHOST_ARTIFACT_DIR", dir)
+
+		mockT := newMockT()
+		testG := ghost.New(namedT{mockT, "TestFoo/bar"})
+
+		testG.Should(ghost.Result{
+			Ok:      false,
+			Message: "some message",
+			Details: &ghost.Details{
+				Location: ghost.Location{File: "/path/to/foo_test.go", Line: 12},
+				Artifacts: map[string]string{
+					"got.txt":  "got\n",
+					"want.txt": "want\n",
+				},
+			},
+		})
+
+		testDir := filepath.Join(dir, "TestFoo", "bar")
+		gotPath := filepath.Join(testDir, "foo_test.go-12-got.txt")
+		wantPath := filepath.Join(testDir, "foo_test.go-12-want.txt")
+
+		g.Should(be.DeepEqual(mockT.logCalls, [][]any{{
+			"some message\n\nfull output written to:\n\t" + gotPath + "\n\t" + wantPath,
+		}}))
+
+		content, err := os.ReadFile(gotPath)
+		g.NoError(err)
+		g.Should(be.Equal(string(content), "got\n"))
+
+		content, err = os.ReadFile(wantPath)
+		g.NoError(err)
+		g.Should(be.Equal(string(content), "want\n"))
+	})
+
+	t.Run("escaped test name", func(t *testing.T) {
+		g := ghost.New(t)
+
+		dir := t.TempDir()
+		t.Setenv("GHOST_ARTIFACT_DIR", filepath.Join(dir, "artifacts"))
+
+		mockT := newMockT()
+		testG := ghost.New(namedT{mockT, "TestFoo/../../x"})
+
+		testG.Should(ghost.Result{
+			Ok:      false,
+			Message: "some message",
+			Details: &ghost.Details{
+				Location:  ghost.Location{File: "/path/to/foo_test.go", Line: 12},
+				Artifacts: map[string]string{"got.txt": "got\n"},
+			},
+		})
+
+		path := filepath.Join(
+			dir, "artifacts", "TestFoo", "%2E%2E", "%2E%2E", "x", "foo_test.go-12-got.txt",
+		)
+		g.Should(be.DeepEqual(mockT.logCalls, [][]any{{
+			"some message\n\nfull output written to:\n\t" + path,
+		}}))
+
+		_, err := os.Stat(filepath.Join(dir, "x"))
+		g.Should(be.True(os.IsNotExist(err)))
+	})
+
+	t.Run("no directory", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_ARTIFACT_DIR", "")
+
+		mockT := newMockT()
+		testG := ghost.New(namedT{mockT, "TestFoo"})
+
+		testG.Should(ghost.Result{
+			Ok:      false,
+			Message: "some message",
+			Details: &ghost.Details{
+				Artifacts: map[string]string{"got.txt": "got\n"},
+			},
+		})
+
+		g.Should(be.DeepEqual(mockT.logCalls, [][]any{{"some message"}}))
+	})
+
+	t.Run("not writable", func(t *testing.T) {
+		g := ghost.New(t)
+
+		file := filepath.Join(t.TempDir(), "file")
+		g.NoError(os.WriteFile(file, nil, 0o600))
+		t.Setenv("GHOST_ARTIFACT_DIR", file)
+
+		mockT := newMockT()
+		testG := ghost.New(namedT{mockT, "TestFoo"})
+
+		testG.Should(ghost.Result{
+			Ok:      false,
+			Message: "some message",
+			Details: &ghost.Details{
+				Artifacts: map[string]string{"got.txt": "got\n"},
+			},
+		})
+
+		g.Must(be.SliceLen(mockT.logCalls, 1))
+		g.Should(be.StringContaining(
+			mockT.logCalls[0][0].(string),
+			"some message\n\nfull output could not be written: ",
+		))
+	})
+}
+
+type namedT struct {
+	*mockT
+	name string
+}
+
+func (t namedT) Name() string { return t.name }
@@ -4,13 +4,20 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/rliebz/ghost"
 	"github.com/rliebz/ghost/ghostlib"
+	"github.com/rliebz/ghost/internal/color"
 	"github.com/rliebz/ghost/internal/constraints"
 	"github.com/rliebz/ghost/internal/jsondiff"
+	"github.com/rliebz/ghost/internal/limits"
+	"github.com/rliebz/ghost/internal/powerassert"
 )
 
 // AssignedAs assigns a value to a target of an arbitrary type.
@@ -20,10 +27,13 @@ func AssignedAs[T any](value any, target *T) ghost.Result {
 	args := ghostlib.ArgsFromAST(value, target)
 	argValue, argTarget := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.AssignedAs", Args: args, Got: value}
+
 	if target == nil {
 		return ghost.Result{
 			Ok:      false,
 			Message: fmt.Sprintf("target %s cannot be nil", argTarget),
+			Details: details,
 		}
 	}
 
@@ -40,6 +50,7 @@ value: %v`,
 				target,
 				value,
 			),
+			Details: details,
 		}
 	}
 
@@ -56,6 +67,7 @@ value: %v`,
 			target,
 			value,
 		),
+		Details: details,
 	}
 }
 
@@ -64,6 +76,8 @@ func Close[T constraints.Integer | constraints.Float](got, want, delta T) ghost.
 	args := ghostlib.ArgsFromAST(got, want, delta)
 	argGot, argWant := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.Close", Args: args, Got: got, Want: want}
+
 	gotDelta := want - got
 	if gotDelta < 0 {
 		gotDelta = 0 - gotDelta
@@ -90,6 +104,7 @@ delta: %v`,
 				want,
 				gotDelta,
 			),
+			Details: details,
 		}
 	}
 
@@ -105,19 +120,39 @@ delta: %v`,
 			want,
 			gotDelta,
 		),
+		Details: details,
 	}
 }
 
 // DeepEqual asserts that two elements are deeply equal.
 func DeepEqual[T any](got, want T) ghost.Result {
 	args := ghostlib.ArgsFromAST(got, want)
+	return deepEqual(got, want, "be.DeepEqual", args)
+}
+
+// DeepEqualWith asserts that two elements are deeply equal, using options to
+// customize the comparison.
+//
+// Any [cmp.Option] can be used, such as those from the cmpopts package, along
+// with options provided by this package such as [IgnoreUnexported],
+// [IgnoreZeroFields], and [IgnoreSliceOrder].
+func DeepEqualWith[T any](got, want T, opts ...cmp.Option) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, want, opts)
+	return deepEqual(got, want, "be.DeepEqualWith", args, opts...)
+}
+
+func deepEqual[T any](got, want T, name string, args []string, opts ...cmp.Option) ghost.Result {
 	argGot, argWant := args[0], args[1]
 
-	if diff := colorDiff(want, got); diff != "" {
+	details := &ghost.Details{Name: name, Args: args, Got: got, Want: want}
+
+	if diff := cmpDiff(want, got, opts...); diff != "" {
+		details.Artifacts = valueArtifacts(got, want, diff)
 		return ghost.Result{
 			Ok: false,
 			Message: fmt.Sprintf(`%v != %v
-%v`, argGot, argWant, diff),
+%v`, argGot, argWant, applyColors(diff)),
+			Details: details,
 		}
 	}
 
@@ -126,20 +161,186 @@ func DeepEqual[T any](got, want T) ghost.Result {
 		Message: fmt.Sprintf(`%v == %v
 value: %v
 `, argGot, argWant, want),
+		Details: details,
+	}
+}
+
+// ElementsMatch asserts that two slices contain the same elements, ignoring
+// their order. Duplicate elements must appear the same number of times in
+// each slice.
+func ElementsMatch[T comparable](got, want []T) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, want)
+
+	// Index unmatched elements of got by value to avoid a quadratic search.
+	unmatched := make(map[T][]int)
+	for i, e := range got {
+		unmatched[e] = append(unmatched[e], i)
+	}
+
+	gotMatched := make([]bool, len(got))
+	wantMatched := make([]bool, len(want))
+	for i, e := range want {
+		if idx := unmatched[e]; len(idx) > 0 {
+			gotMatched[idx[0]] = true
+			wantMatched[i] = true
+			unmatched[e] = idx[1:]
+		}
+	}
+
+	equal := func(a, b T) bool { return a == b }
+	return elementsMatch(got, want, gotMatched, wantMatched, equal, "be.ElementsMatch", args)
+}
+
+// ElementsDeepMatch asserts that two slices contain deeply equal elements,
+// ignoring their order. Duplicate elements must appear the same number of
+// times in each slice.
+func ElementsDeepMatch[T any](got, want []T) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, want)
+
+	equal := func(a, b T) bool { return cmp.Equal(a, b, exportTypes) }
+
+	gotMatched := make([]bool, len(got))
+	wantMatched := make([]bool, len(want))
+	for i, w := range want {
+		for j, g := range got {
+			if !gotMatched[j] && equal(g, w) {
+				gotMatched[j] = true
+				wantMatched[i] = true
+				break
+			}
+		}
+	}
+
+	return elementsMatch(got, want, gotMatched, wantMatched, equal, "be.ElementsDeepMatch", args)
+}
+
+func elementsMatch[T any](
+	got []T,
+	want []T,
+	gotMatched []bool,
+	wantMatched []bool,
+	equal func(a, b T) bool,
+	name string,
+	args []string,
+) ghost.Result {
+	argGot, argWant := args[0], args[1]
+
+	details := &ghost.Details{Name: name, Args: args, Got: got, Want: want}
+
+	missing := countUnmatched(want, wantMatched, equal)
+	extra := countUnmatched(got, gotMatched, equal)
+
+	if len(missing) == 0 && len(extra) == 0 {
+		return ghost.Result{
+			Ok: true,
+			Message: fmt.Sprintf(`%v has the same elements as %v
+got:  %v
+want: %v
+`,
+				argGot,
+				argWant,
+				sliceToString(got),
+				sliceToString(want),
+			),
+			Details: details,
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `%v does not have the same elements as %v
+got:  %v
+want: %v
+`,
+		argGot,
+		argWant,
+		sliceMarkedToString(got, func(i int) bool { return !gotMatched[i] }),
+		sliceMarkedToString(want, func(i int) bool { return !wantMatched[i] }),
+	)
+
+	writeElementCounts(&sb, "missing", missing, color.Red)
+	writeElementCounts(&sb, "extra", extra, color.Green)
+
+	return ghost.Result{
+		Ok:      false,
+		Message: sb.String(),
+		Details: details,
 	}
 }
 
+// writeElementCounts lists elements under a heading, if there are any.
+func writeElementCounts[T any](
+	sb *strings.Builder,
+	heading string,
+	counts []elementCount[T],
+	paint func(string) string,
+) {
+	if len(counts) == 0 {
+		return
+	}
+
+	limit := limits.Elements()
+
+	fmt.Fprintf(sb, "%s:\n", heading)
+	for i, c := range counts {
+		if limits.Exceeded(i+1, limit) {
+			fmt.Fprintf(sb, "\t%s\n", limits.Omitted(len(counts)-i, "element", "elements"))
+			return
+		}
+
+		sb.WriteString(paint(c.String()))
+		sb.WriteByte('\n')
+	}
+}
+
+// elementCount is an element of a slice along with how many times it occurs.
+type elementCount[T any] struct {
+	element T
+	count   int
+}
+
+func (c elementCount[T]) String() string {
+	if c.count == 1 {
+		return fmt.Sprintf("\t%v", c.element)
+	}
+	return fmt.Sprintf("\t%v (x%d)", c.element, c.count)
+}
+
+// countUnmatched groups the unmatched elements of a slice, in order of their
+// first appearance.
+func countUnmatched[T any](slice []T, matched []bool, equal func(a, b T) bool) []elementCount[T] {
+	var counts []elementCount[T]
+outer:
+	for i, e := range slice {
+		if matched[i] {
+			continue
+		}
+
+		for j := range counts {
+			if equal(counts[j].element, e) {
+				counts[j].count++
+				continue outer
+			}
+		}
+
+		counts = append(counts, elementCount[T]{element: e, count: 1})
+	}
+	return counts
+}
+
 // Equal asserts that two elements are equal.
 func Equal[T comparable](got T, want T) ghost.Result {
 	args := ghostlib.ArgsFromAST(got, want)
 	argGot, argWant := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.Equal", Args: args, Got: got, Want: want}
+
 	if got == want {
 		switch fmt.Sprint(want) {
 		case argGot, argWant:
 			return ghost.Result{
 				Ok:      true,
 				Message: fmt.Sprintf(`%v == %v`, argGot, argWant),
+				Details: details,
 			}
 		default:
 			return ghost.Result{
@@ -147,6 +348,7 @@ func Equal[T comparable](got T, want T) ghost.Result {
 				Message: fmt.Sprintf(`%v == %v
 value: %v
 `, argGot, argWant, want),
+				Details: details,
 			}
 		}
 	}
@@ -161,33 +363,22 @@ value: %v
 		reflect.Slice,
 		reflect.Struct:
 
+		diff := cmpDiff(want, got)
+		details.Artifacts = valueArtifacts(got, want, diff)
 		return ghost.Result{
 			Ok: false,
 			Message: fmt.Sprintf(`%v != %v
-%v`, argGot, argWant, colorDiff(want, got)),
+%v`, argGot, argWant, applyColors(diff)),
+			Details: details,
 		}
 	case reflect.String:
-		if strings.ContainsAny(v.String(), "\n\r") ||
-			strings.ContainsAny(reflect.ValueOf(got).String(), "\n\r") {
-
-			return ghost.Result{
-				Ok: false,
-				Message: fmt.Sprintf(`%v != %v
-%v`, argGot, argWant, colorDiff(want, got)),
-			}
-		}
-
+		gotString, wantString := reflect.ValueOf(got).String(), v.String()
+		details.Artifacts = stringArtifacts(gotString, wantString)
 		return ghost.Result{
 			Ok: false,
 			Message: fmt.Sprintf(`%v != %v
-got:  %v
-want: %v
-`,
-				argGot,
-				argWant,
-				quoteString(reflect.ValueOf(got).String()),
-				quoteString(reflect.ValueOf(want).String()),
-			),
+%v`, argGot, argWant, stringDiff(gotString, wantString)),
+			Details: details,
 		}
 	}
 
@@ -197,73 +388,269 @@ want: %v
 got:  %v
 want: %v
 `, argGot, argWant, got, want),
+		Details: details,
 	}
 }
 
 // quoteString prints a string as a single quoted line, or multiline block.
 func quoteString(s string) string {
-	if strings.ContainsAny(s, "\n\r") {
+	if strings.Contains(s, "\n") && utf8.ValidString(s) && printableLines(s) {
 		return fmt.Sprintf(`
 """
 %s
 """`, s)
 	}
 
-	return fmt.Sprintf("%q", s)
+	return strconv.Quote(s)
+}
+
+// printableLines reports whether a string can be shown as a block of lines
+// without escaping, with no control characters other than newlines and tabs.
+func printableLines(s string) bool {
+	for _, r := range s {
+		if r != '\n' && r != '\t' && !strconv.IsPrint(r) {
+			return false
+		}
+	}
+	return true
 }
 
 // False asserts that a value is false.
-func False(b bool) ghost.Result {
+//
+// If captures are given, the values recorded with [Capture] are drawn in a
+// diagram beneath the expression.
+func False(b bool, captures ...*Captures) ghost.Result {
+	captured := take(captures)
 	args := ghostlib.ArgsFromAST(b)
 	argB := args[0]
 
+	details := &ghost.Details{Name: "be.False", Args: args, Got: b, Want: false}
+
+	if expr, diagram, ok := powerassert.Render(argB, b, "Capture", captured); ok {
+		return ghost.Result{
+			Ok: !b,
+			Message: fmt.Sprintf(`%v is %t
+%v`, expr, b, diagram),
+			Details: details,
+		}
+	}
+
 	return ghost.Result{
 		Ok:      !b,
 		Message: fmt.Sprintf("%v is %t", argB, b),
+		Details: details,
 	}
 }
 
 // JSONEqual asserts that two sets of JSON-encoded data are equivalent.
 func JSONEqual[T ~string | ~[]byte](got, want T) ghost.Result {
 	args := ghostlib.ArgsFromAST(got, want)
+	return decodedEqual(got, want, jsonFormat, "be.JSONEqual", args, jsondiff.Options{})
+}
+
+// A format is a text encoding that can be compared as decoded JSON values.
+type format struct {
+	name   string
+	decode func([]byte) (any, error)
+}
+
+var jsonFormat = format{name: "JSON", decode: jsondiff.Decode[[]byte]}
+
+// decodedEqual compares two inputs after decoding them with a format.
+func decodedEqual[T ~string | ~[]byte](
+	got T,
+	want T,
+	f format,
+	name string,
+	args []string,
+	opts jsondiff.Options,
+) ghost.Result {
 	argGot, argWant := args[0], args[1]
 
-	diff, kind := colorJSONDiff(got, want)
+	details := &ghost.Details{Name: name, Args: args, Got: got, Want: want}
 
-	switch kind {
-	case jsondiff.Match:
+	opts.Summary = true
+	diff, kind := decodedDiff(got, want, f, opts)
+
+	if kind == jsondiff.Match {
 		return ghost.Result{
 			Ok:      true,
-			Message: fmt.Sprintf("%v and %v are JSON equal", argGot, argWant),
+			Message: fmt.Sprintf("%v and %v are %s equal", argGot, argWant, f.name),
+			Details: details,
 		}
+	}
+
+	if result, ok := invalidInput(f, kind, got, want, args, details); ok {
+		return result
+	}
+
+	details.Artifacts = decodedArtifacts(f, string(got), string(want), diff)
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(`%v and %v are not %s equal
+%s`, argGot, argWant, f.name, applyColors(diff)),
+		Details: details,
+	}
+}
+
+// invalidInput returns a failing result if either input is not valid in the
+// format.
+func invalidInput[T ~string | ~[]byte](
+	f format,
+	kind jsondiff.Kind,
+	got T,
+	want T,
+	args []string,
+	details *ghost.Details,
+) (ghost.Result, bool) {
+	argGot, argWant := args[0], args[1]
+
+	switch kind {
 	case jsondiff.GotInvalid:
 		return ghost.Result{
 			Ok: false,
-			Message: fmt.Sprintf(`%v is not valid JSON
-value: %s`, argGot, got),
-		}
+			Message: fmt.Sprintf(`%v is not valid %s
+value: %s`, argGot, f.name, got),
+			Details: details,
+		}, true
 	case jsondiff.WantInvalid:
 		return ghost.Result{
 			Ok: false,
-			Message: fmt.Sprintf(`%v is not valid JSON
-value: %s`, argWant, want),
-		}
+			Message: fmt.Sprintf(`%v is not valid %s
+value: %s`, argWant, f.name, want),
+			Details: details,
+		}, true
 	case jsondiff.BothInvalid:
 		return ghost.Result{
 			Ok: false,
-			Message: fmt.Sprintf(`%v and %v are not valid JSON
+			Message: fmt.Sprintf(`%v and %v are not valid %s
 got:
 %s
 
 want:
-%s`, argGot, argWant, got, want),
+%s`, argGot, argWant, f.name, got, want),
+			Details: details,
+		}, true
+	}
+
+	return ghost.Result{}, false
+}
+
+// MapContaining asserts that every key of a wanted map is in a map, and that
+// its value in the map is deeply equal to the wanted value.
+func MapContaining[K comparable, V any](got, want map[K]V) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, want)
+	argGot, argWant := args[0], args[1]
+
+	details := &ghost.Details{Name: "be.MapContaining", Args: args, Got: got, Want: want}
+
+	ok := true
+	for k, w := range want {
+		if g, found := got[k]; !found || !cmp.Equal(g, w, exportTypes) {
+			ok = false
+			break
+		}
+	}
+
+	if ok {
+		return ghost.Result{
+			Ok: true,
+			Message: fmt.Sprintf(`%v contains %v
+got:  %v
+want: %v
+`, argGot, argWant, mapToString(got), mapToString(want)),
+			Details: details,
+		}
+	}
+
+	diff := mapLinesToString(sortedKeys(got, want), func(k K) string {
+		g, inGot := got[k]
+		w, inWant := want[k]
+		switch {
+		case !inWant:
+			return mapEntryToString(k, g)
+		case !inGot:
+			return color.Red("-" + mapEntryToString(k, w))
+		case !cmp.Equal(g, w, exportTypes):
+			return color.Red("-"+mapEntryToString(k, w)) + "\n" +
+				color.Green("+"+mapEntryToString(k, g))
+		default:
+			return mapEntryToString(k, g)
+		}
+	})
+
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(`%v does not contain %v
+diff (%s %s):
+%v
+`, argGot, argWant, color.Red("-want"), color.Green("+got"), diff),
+		Details: details,
+	}
+}
+
+// MapHasKey asserts that a key exists in a given map.
+func MapHasKey[K comparable, V any](m map[K]V, key K) ghost.Result {
+	args := ghostlib.ArgsFromAST(m, key)
+	argMap, argKey := args[0], args[1]
+
+	details := &ghost.Details{Name: "be.MapHasKey", Args: args, Got: m, Want: key}
+
+	isKey := func(k K) bool { return k == key }
+
+	if _, ok := m[key]; ok {
+		return ghost.Result{
+			Ok: true,
+			Message: fmt.Sprintf(`%v has key %v
+map: %v
+key: %v
+`, argMap, argKey, mapMarkedToString(m, isKey), key),
+			Details: details,
 		}
 	}
 
 	return ghost.Result{
 		Ok: false,
-		Message: fmt.Sprintf(`%v and %v are not JSON equal
-%s`, argGot, argWant, diff),
+		Message: fmt.Sprintf(`%v does not have key %v
+map: %v
+key: %v
+`, argMap, argKey, mapToString(m), color.Red(fmt.Sprint(key))),
+		Details: details,
+	}
+}
+
+// MapLacksKey asserts that a key does not exist in a given map.
+func MapLacksKey[K comparable, V any](m map[K]V, key K) ghost.Result {
+	args := ghostlib.ArgsFromAST(m, key)
+	argMap, argKey := args[0], args[1]
+
+	details := &ghost.Details{Name: "be.MapLacksKey", Args: args, Got: m, Want: key}
+
+	if _, ok := m[key]; ok {
+		marked := mapLinesToString(sortedKeys(m), func(k K) string {
+			if k == key {
+				return color.Green(">" + mapEntryToString(k, m[k]))
+			}
+			return mapEntryToString(k, m[k])
+		})
+
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%v has key %v
+map: %v
+key: %v
+`, argMap, argKey, marked, key),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: true,
+		Message: fmt.Sprintf(`%v does not have key %v
+map: %v
+key: %v
+`, argMap, argKey, mapToString(m), key),
+		Details: details,
 	}
 }
 
@@ -272,12 +659,15 @@ func MapLen[K comparable, V any](got map[K]V, want int) ghost.Result {
 	args := ghostlib.ArgsFromAST(got, want)
 	argGot := args[0]
 
+	details := &ghost.Details{Name: "be.MapLen", Args: args, Got: len(got), Want: want}
+
 	if len(got) == want {
 		return ghost.Result{
 			Ok: true,
 			Message: fmt.Sprintf(`%v is length %d
 map: %v
 `, argGot, len(got), mapToString(got)),
+			Details: details,
 		}
 	}
 
@@ -286,37 +676,90 @@ map: %v
 		Message: fmt.Sprintf(`%v is length %d, not %d
 map: %v
 `, argGot, len(got), want, mapToString(got)),
+		Details: details,
 	}
 }
 
 // mapToString pretty prints a map.
 func mapToString[K comparable, V any](m map[K]V) string {
+	return mapLinesToString(sortedKeys(m), func(k K) string {
+		return mapEntryToString(k, m[k])
+	})
+}
+
+// mapMarkedToString pretty prints a map, highlighting any marked entries.
+func mapMarkedToString[K comparable, V any](m map[K]V, marked func(k K) bool) string {
+	return mapLinesToString(sortedKeys(m), func(k K) string {
+		if marked(k) {
+			return ">" + mapEntryToString(k, m[k])
+		}
+		return mapEntryToString(k, m[k])
+	})
+}
+
+// mapLinesToString pretty prints a map using one or more lines per key.
+func mapLinesToString[K comparable](keys []K, lines func(k K) string) string {
+	limit := limits.Elements()
+
 	var sb strings.Builder
 	sb.WriteString("{\n")
-	for k, v := range m {
-		sb.WriteByte('\t')
-		fmt.Fprintf(&sb, "%v: %v", k, v)
-		sb.WriteString(",\n")
+	for i, k := range keys {
+		if limits.Exceeded(i+1, limit) {
+			fmt.Fprintf(&sb, "\t%s\n", limits.Omitted(len(keys)-i, "entry", "entries"))
+			break
+		}
+
+		sb.WriteString(lines(k))
+		sb.WriteByte('\n')
 	}
 	sb.WriteString("}")
 	return sb.String()
 }
 
+func mapEntryToString[K comparable, V any](k K, v V) string {
+	return fmt.Sprintf("\t%v: %v,", k, v)
+}
+
+// sortedKeys returns the keys across every map, sorted by their string
+// representation so that output is stable.
+func sortedKeys[K comparable, V any](maps ...map[K]V) []K {
+	seen := make(map[K]bool)
+	var keys []K
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	return keys
+}
+
 // Nil asserts that the given value is nil.
 func Nil(v any) ghost.Result {
 	args := ghostlib.ArgsFromAST(v)
 	argV := args[0]
 
+	details := &ghost.Details{Name: "be.Nil", Args: args, Got: v}
+
 	if isNil(v) {
 		return ghost.Result{
 			Ok:      true,
 			Message: fmt.Sprintf("%v is nil", argV),
+			Details: details,
 		}
 	}
 
 	return ghost.Result{
 		Ok:      false,
 		Message: fmt.Sprintf("%v is %v, not nil", argV, v),
+		Details: details,
 	}
 }
 
@@ -346,6 +789,8 @@ func SliceContaining[T comparable](slice []T, element T) ghost.Result {
 	args := ghostlib.ArgsFromAST(slice, element)
 	argSlice, argElement := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.SliceContaining", Args: args, Got: slice, Want: element}
+
 	for _, x := range slice {
 		if x == element {
 			return ghost.Result{
@@ -359,6 +804,7 @@ element: %v
 					sliceElementToString(slice, element),
 					element,
 				),
+				Details: details,
 			}
 		}
 	}
@@ -374,19 +820,148 @@ element: %v
 			sliceElementToString(slice, element),
 			element,
 		),
+		Details: details,
+	}
+}
+
+// SliceContainingAll asserts that every one of the given elements exists in a
+// slice.
+func SliceContainingAll[T comparable](slice []T, elements ...T) ghost.Result {
+	args := ghostlib.ArgsFromAST(slice, elements)
+	argSlice, argElements := args[0], strings.Join(args[1:], ", ")
+
+	details := &ghost.Details{Name: "be.SliceContainingAll", Args: args, Got: slice, Want: elements}
+
+	found := make(map[T]bool, len(slice))
+	for _, e := range slice {
+		found[e] = true
+	}
+
+	var missing []T
+	for _, e := range elements {
+		if !found[e] {
+			missing = append(missing, e)
+		}
+	}
+
+	isElement := markElements(slice, elements)
+
+	if len(missing) == 0 {
+		return ghost.Result{
+			Ok: true,
+			Message: fmt.Sprintf(`%v contains all of %v
+slice:    %v
+elements: %v
+`,
+				argSlice,
+				argElements,
+				sliceMarkedToString(slice, isElement),
+				elements,
+			),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(`%v does not contain all of %v
+slice:    %v
+elements: %v
+missing:  %v
+`,
+			argSlice,
+			argElements,
+			sliceMarkedToString(slice, isElement),
+			elements,
+			color.Red(fmt.Sprint(missing)),
+		),
+		Details: details,
+	}
+}
+
+// SliceContainingNone asserts that none of the given elements exist in a
+// slice.
+func SliceContainingNone[T comparable](slice []T, elements ...T) ghost.Result {
+	args := ghostlib.ArgsFromAST(slice, elements)
+	argSlice, argElements := args[0], strings.Join(args[1:], ", ")
+
+	details := &ghost.Details{Name: "be.SliceContainingNone", Args: args, Got: slice, Want: elements}
+
+	isElement := markElements(slice, elements)
+
+	var found []T
+	for i, e := range slice {
+		if isElement(i) {
+			found = append(found, e)
+		}
+	}
+
+	if len(found) == 0 {
+		return ghost.Result{
+			Ok: true,
+			Message: fmt.Sprintf(`%v contains none of %v
+slice:    %v
+elements: %v
+`,
+				argSlice,
+				argElements,
+				sliceToString(slice),
+				elements,
+			),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(`%v contains some of %v
+slice:    %v
+elements: %v
+found:    %v
+`,
+			argSlice,
+			argElements,
+			sliceMarkedToString(slice, isElement),
+			elements,
+			color.Green(fmt.Sprint(found)),
+		),
+		Details: details,
 	}
 }
 
+// markElements returns a function that reports whether the element at an
+// index of a slice is any of the given elements.
+func markElements[T comparable](slice []T, elements []T) func(i int) bool {
+	set := make(map[T]bool, len(elements))
+	for _, e := range elements {
+		set[e] = true
+	}
+
+	return func(i int) bool { return set[slice[i]] }
+}
+
 // sliceElementToString pretty prints a slice, highlighting an element if it exists.
 func sliceElementToString[T comparable](slice []T, element T) string {
+	return sliceMarkedToString(slice, func(i int) bool { return slice[i] == element })
+}
+
+// sliceMarkedToString pretty prints a slice, highlighting any marked elements.
+func sliceMarkedToString[T any](slice []T, marked func(i int) bool) string {
 	if len(slice) <= 3 {
 		return fmt.Sprint(slice)
 	}
 
+	limit := limits.Elements()
+
 	var sb strings.Builder
 	sb.WriteString("[\n")
-	for _, e := range slice {
-		if e == element {
+	for i, e := range slice {
+		if limits.Exceeded(i+1, limit) {
+			fmt.Fprintf(&sb, "\t%s\n", limits.Omitted(len(slice)-i, "element", "elements"))
+			break
+		}
+
+		if marked(i) {
 			sb.WriteByte('>')
 		}
 
@@ -403,12 +978,15 @@ func SliceLen[T any](got []T, want int) ghost.Result {
 	args := ghostlib.ArgsFromAST(got, want)
 	argGot := args[0]
 
+	details := &ghost.Details{Name: "be.SliceLen", Args: args, Got: len(got), Want: want}
+
 	if len(got) == want {
 		return ghost.Result{
 			Ok: true,
 			Message: fmt.Sprintf(`%v is length %d
 slice: %v
 `, argGot, len(got), sliceToString(got)),
+			Details: details,
 		}
 	}
 
@@ -417,24 +995,13 @@ slice: %v
 		Message: fmt.Sprintf(`%v is length %d, not %d
 slice: %v
 `, argGot, len(got), want, sliceToString(got)),
+		Details: details,
 	}
 }
 
 // sliceToString pretty prints a slice.
 func sliceToString[T any](slice []T) string {
-	if len(slice) <= 3 {
-		return fmt.Sprint(slice)
-	}
-
-	var sb strings.Builder
-	sb.WriteString("[\n")
-	for _, e := range slice {
-		sb.WriteByte('\t')
-		fmt.Fprint(&sb, e)
-		sb.WriteByte('\n')
-	}
-	sb.WriteString("]")
-	return sb.String()
+	return sliceMarkedToString(slice, func(int) bool { return false })
 }
 
 // StringContaining asserts that a substring exists in a given string.
@@ -442,6 +1009,8 @@ func StringContaining(str, substr string) ghost.Result {
 	args := ghostlib.ArgsFromAST(str, substr)
 	argStr, argSubstr := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.StringContaining", Args: args, Got: str, Want: substr}
+
 	if strings.Contains(str, substr) {
 		return ghost.Result{
 			Ok: true,
@@ -449,6 +1018,7 @@ func StringContaining(str, substr string) ghost.Result {
 str:    %s
 substr: %s
 `, argStr, argSubstr, quoteString(str), quoteString(substr)),
+			Details: details,
 		}
 	}
 
@@ -458,6 +1028,7 @@ substr: %s
 str:    %s
 substr: %s
 `, argStr, argSubstr, quoteString(str), quoteString(substr)),
+		Details: details,
 	}
 }
 
@@ -466,6 +1037,8 @@ func StringMatching(str string, expr string) ghost.Result {
 	args := ghostlib.ArgsFromAST(str, expr)
 	argStr, argExpr := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.StringMatching", Args: args, Got: str, Want: expr}
+
 	re, err := regexp.Compile(expr)
 	if err != nil {
 		return ghost.Result{
@@ -476,6 +1049,7 @@ func StringMatching(str string, expr string) ghost.Result {
 				argExpr,
 				err,
 			),
+			Details: details,
 		}
 	}
 
@@ -490,6 +1064,7 @@ expr: %s
 				quoteString(str),
 				re.String(),
 			),
+			Details: details,
 		}
 	}
 
@@ -503,17 +1078,34 @@ expr: %s
 			quoteString(str),
 			re.String(),
 		),
+		Details: details,
 	}
 }
 
 // True asserts that a value is true.
-func True(b bool) ghost.Result {
+//
+// If captures are given, the values recorded with [Capture] are drawn in a
+// diagram beneath the expression.
+func True(b bool, captures ...*Captures) ghost.Result {
+	captured := take(captures)
 	args := ghostlib.ArgsFromAST(b)
 	argB := args[0]
 
+	details := &ghost.Details{Name: "be.True", Args: args, Got: b, Want: true}
+
+	if expr, diagram, ok := powerassert.Render(argB, b, "Capture", captured); ok {
+		return ghost.Result{
+			Ok: b,
+			Message: fmt.Sprintf(`%v is %t
+%v`, expr, b, diagram),
+			Details: details,
+		}
+	}
+
 	return ghost.Result{
 		Ok:      b,
 		Message: fmt.Sprintf("%v is %t", argB, b),
+		Details: details,
 	}
 }
 
@@ -522,11 +1114,14 @@ func Zero[T comparable](v T) ghost.Result {
 	args := ghostlib.ArgsFromAST(v)
 	argV := args[0]
 
+	details := &ghost.Details{Name: "be.Zero", Args: args, Got: v}
+
 	var zero T
 	if v == zero {
 		return ghost.Result{
 			Ok:      true,
 			Message: fmt.Sprintf("%v is the zero value", argV),
+			Details: details,
 		}
 	}
 
@@ -534,11 +1129,13 @@ func Zero[T comparable](v T) ghost.Result {
 		return ghost.Result{
 			Ok:      false,
 			Message: fmt.Sprintf("%v is non-zero\nvalue: %v", argV, v),
+			Details: details,
 		}
 	}
 
 	return ghost.Result{
 		Ok:      false,
 		Message: fmt.Sprintf("%v is non-zero", argV),
+		Details: details,
 	}
 }
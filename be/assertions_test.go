@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/rliebz/ghost"
 	"github.com/rliebz/ghost/be"
 )
@@ -242,6 +244,239 @@ diff (-want +got):
 	})
 }
 
+func TestDeepEqualWith(t *testing.T) {
+	type T struct {
+		A string
+		B []int
+		c int
+	}
+
+	t.Run("no options", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := T{"foo", []int{1, 2}, 0}
+		want := T{"foo", []int{1, 2}, 1}
+
+		result := be.DeepEqualWith(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(result.Message, "got != want"))
+	})
+
+	t.Run("options not modified", func(t *testing.T) {
+		g := ghost.New(t)
+
+		opts := make([]cmp.Option, 1, 2)
+		opts[0] = be.IgnoreUnexported()
+
+		result := be.DeepEqualWith(T{A: "foo"}, T{A: "foo"}, opts...)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Nil(opts[:2][1]))
+	})
+
+	t.Run("ignore unexported", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := T{"foo", []int{1, 2}, 0}
+		want := T{"foo", []int{1, 2}, 1}
+
+		result := be.DeepEqualWith(got, want, be.IgnoreUnexported())
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `got == want
+value: {foo [1 2] 1}
+`))
+	})
+
+	t.Run("ignore zero fields", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := T{"foo", []int{1, 2}, 3}
+
+		result := be.DeepEqualWith(got, T{A: "foo"}, be.IgnoreZeroFields())
+		g.Should(be.True(result.Ok))
+
+		result = be.DeepEqualWith(got, T{A: "bar"}, be.IgnoreZeroFields())
+		g.Should(be.False(result.Ok))
+
+		result.Message = strings.ReplaceAll(result.Message, "\u00a0", " ")
+		g.Should(be.Equal(result.Message, `got != T{A: "bar"}
+diff (-want +got):
+  be_test.T{
+- 	A: "bar",
++ 	A: "foo",
+  	... // 2 ignored fields
+  }
+`))
+	})
+
+	t.Run("ignore slice order", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := T{"foo", []int{2, 1, 2}, 0}
+
+		result := be.DeepEqualWith(got, T{"foo", []int{2, 2, 1}, 0}, be.IgnoreSliceOrder())
+		g.Should(be.True(result.Ok))
+
+		result = be.DeepEqualWith(got, T{"foo", []int{1, 2, 3}, 0}, be.IgnoreSliceOrder())
+		g.Should(be.False(result.Ok))
+	})
+
+	t.Run("ignore slice order of named types", func(t *testing.T) {
+		g := ghost.New(t)
+
+		type Color string
+
+		result := be.DeepEqualWith(
+			[]Color{"red", "blue"},
+			[]Color{"blue", "red"},
+			be.IgnoreSliceOrder(),
+		)
+		g.Should(be.True(result.Ok))
+
+		result = be.DeepEqualWith([]float64{1.5, -2}, []float64{-2, 1.5}, be.IgnoreSliceOrder())
+		g.Should(be.True(result.Ok))
+	})
+
+	t.Run("ignore slice order of unordered types", func(t *testing.T) {
+		g := ghost.New(t)
+
+		a, b := 1, 2
+
+		result := be.DeepEqualWith([]*int{&a, &b}, []*int{&b, &a}, be.IgnoreSliceOrder())
+		g.Should(be.False(result.Ok))
+
+		result = be.DeepEqualWith(
+			[]T{{A: "foo"}, {A: "bar"}},
+			[]T{{A: "bar"}, {A: "foo"}},
+			be.IgnoreSliceOrder(),
+		)
+		g.Should(be.False(result.Ok))
+	})
+}
+
+func TestElementsMatch(t *testing.T) {
+	t.Run("match", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := []int{3, 1, 2, 1}
+		want := []int{1, 1, 2, 3}
+
+		result := be.ElementsMatch(got, want)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `got has the same elements as want
+got:  [
+	3
+	1
+	2
+	1
+]
+want: [
+	1
+	1
+	2
+	3
+]
+`))
+
+		result = be.ElementsMatch([]string{"b", "a"}, []string{"a", "b"})
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `[]string{"b", "a"} has the same elements as `+
+			`[]string{"a", "b"}
+got:  [b a]
+want: [a b]
+`))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.ElementsMatch([]int{}, nil)
+		g.Should(be.True(result.Ok))
+	})
+
+	t.Run("missing and extra", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := []int{1, 4, 2, 5}
+		want := []int{3, 1, 3, 2}
+
+		result := be.ElementsMatch(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got does not have the same elements as want
+got:  [
+	1
+>	4
+	2
+>	5
+]
+want: [
+>	3
+	1
+>	3
+	2
+]
+missing:
+	3 (x2)
+extra:
+	4
+	5
+`))
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.ElementsMatch([]string{"a", "a", "b"}, []string{"a"})
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `[]string{"a", "a", "b"} does not have the same elements as `+
+			`[]string{"a"}
+got:  [a a b]
+want: [a]
+extra:
+	a
+	b
+`))
+	})
+}
+
+func TestElementsDeepMatch(t *testing.T) {
+	type T struct {
+		Name string
+		Tags []string
+	}
+
+	t.Run("match", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := []T{{"b", []string{"x"}}, {"a", nil}}
+		want := []T{{"a", nil}, {"b", []string{"x"}}}
+
+		result := be.ElementsDeepMatch(got, want)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `got has the same elements as want
+got:  [{b [x]} {a []}]
+want: [{a []} {b [x]}]
+`))
+	})
+
+	t.Run("missing and extra", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := []T{{"a", []string{"x"}}, {"b", nil}}
+		want := []T{{"a", []string{"y"}}, {"a", []string{"y"}}, {"b", nil}}
+
+		result := be.ElementsDeepMatch(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got does not have the same elements as want
+got:  [{a [x]} {b []}]
+want: [{a [y]} {a [y]} {b []}]
+missing:
+	{a [y]} (x2)
+extra:
+	{a [x]}
+`))
+	})
+}
+
 func TestEqual(t *testing.T) {
 	t.Run("equal", func(t *testing.T) {
 		g := ghost.New(t)
@@ -338,12 +573,14 @@ want: "foo"
 
 		wantText := `got != want
 diff (-want +got):
-  string(
-- 	"foo\nbar\nbaz",
-+ 	"bar",
-  )
+@@ -1,3 +1 @@
+-foo
+-bar
+-baz
+\ No newline at end of file
++bar
+\ No newline at end of file
 `
-		result.Message = strings.ReplaceAll(result.Message, "\u00a0", " ")
 		g.Should(be.Equal(result.Message, wantText))
 
 		result = be.Equal("bar", "foo\nbar\nbaz")
@@ -351,15 +588,78 @@ diff (-want +got):
 
 		wantText = `"bar" != "foo\nbar\nbaz"
 diff (-want +got):
-  string(
-- 	"foo\nbar\nbaz",
-+ 	"bar",
-  )
+@@ -1,3 +1 @@
+-foo
+-bar
+-baz
+\ No newline at end of file
++bar
+\ No newline at end of file
 `
-		result.Message = strings.ReplaceAll(result.Message, "\u00a0", " ")
 		g.Should(be.Equal(result.Message, wantText))
 	})
 
+	t.Run("unequal multiline strings", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := "level=info msg=start\nlevel=warn msg=slow\nlevel=info msg=done\n"
+		want := "level=info msg=start\nlevel=info msg=done\n"
+
+		result := be.Equal(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got != want
+diff (-want +got):
+@@ -1,2 +1,3 @@
+ level=info msg=start
++level=warn msg=slow
+ level=info msg=done
+`))
+
+		t.Setenv("GHOST_DIFF_CONTEXT", "0")
+
+		result = be.Equal(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got != want
+diff (-want +got):
+@@ -1,0 +2 @@
++level=warn msg=slow
+`))
+	})
+
+	t.Run("unequal carriage returns", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := "a\rb"
+		want := "a\rc"
+
+		result := be.Equal(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got != want
+got:  "a\rb"
+          ^
+want: "a\rc"
+          ^
+`))
+	})
+
+	t.Run("dissimilar carriage returns", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.Equal("a\rb", "xyz")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `"a\rb" != "xyz"
+got:  "a\rb"
+want: "xyz"
+`))
+
+		result = be.StringContaining("a\r\nb", "z")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `"a\r\nb" does not contain "z"
+str:    "a\r\nb"
+substr: "z"
+`))
+	})
+
 	t.Run("unequal struct", func(t *testing.T) {
 		g := ghost.New(t)
 
@@ -425,6 +725,90 @@ got:  "foo"
 want: "bar"
 `))
 	})
+
+	t.Run("similar strings", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := "https://example.com/users/42?active=true"
+		want := "https://example.com/users/24?active=true"
+
+		result := be.Equal(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got != want
+got:  "https://example.com/users/42?active=true"
+                                  ^
+want: "https://example.com/users/24?active=true"
+                                 ^
+`))
+
+		got = "SELECT id FROM users"
+		want = "SELECT id, name FROM users"
+
+		result = be.Equal(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got != want
+got:  "SELECT id FROM users"
+want: "SELECT id, name FROM users"
+                ^^^^^^
+`))
+	})
+
+	t.Run("invisible differences", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.Equal("foo ", "foo")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `"foo " != "foo"
+got:  "foo "
+          ^
+want: "foo"
+note: got and want differ only in trailing whitespace
+`))
+
+		result = be.Equal("a\tb", "a  b")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `"a\tb" != "a  b"
+got:  "a\tb"
+        ^^
+want: "a  b"
+        ^^
+note: got and want differ only in tabs and spaces
+`))
+
+		result = be.Equal("caf\u00e9", "cafe\u0301")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, "\"caf\\u00e9\" != \"cafe\\u0301\"\n"+
+			"got:  \"caf\u00e9\"\n"+
+			"          ^\n"+
+			"want: \"cafe\u0301\"\n"+
+			"          ^^\n"+
+			"note: got and want are equal after Unicode normalization\n"))
+
+		result = be.Equal("ab\u200bc", "abc")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `"ab\u200bc" != "abc"
+got:  "ab\u200bc"
+         ^^^^^^
+want: "abc"
+note: got and want differ only in zero-width characters
+`))
+	})
+
+	t.Run("details", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := 0
+		want := 1
+
+		result := be.Equal(got, want)
+		g.Must(be.Not(be.Nil(result.Details)))
+		g.Should(be.DeepEqual(*result.Details, ghost.Details{
+			Name: "be.Equal",
+			Args: []string{"got", "want"},
+			Got:  0,
+			Want: 1,
+		}))
+	})
 }
 
 func TestFalse(t *testing.T) {
@@ -530,6 +914,153 @@ want:
 	})
 }
 
+func TestMapContaining(t *testing.T) {
+	t.Run("contains", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := map[string]int{"a": 1, "b": 2, "c": 3}
+		want := map[string]int{"a": 1, "c": 3}
+
+		result := be.MapContaining(got, want)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `got contains want
+got:  {
+	a: 1,
+	b: 2,
+	c: 3,
+}
+want: {
+	a: 1,
+	c: 3,
+}
+`))
+
+		result = be.MapContaining(got, map[string]int{})
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `got contains map[string]int{}
+got:  {
+	a: 1,
+	b: 2,
+	c: 3,
+}
+want: {
+}
+`))
+	})
+
+	t.Run("deep values", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := map[string][]int{"a": {1, 2}, "b": {3}}
+
+		result := be.MapContaining(got, map[string][]int{"a": {1, 2}})
+		g.Should(be.True(result.Ok))
+
+		result = be.MapContaining(got, map[string][]int{"a": {2, 1}})
+		g.Should(be.False(result.Ok))
+	})
+
+	t.Run("does not contain", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := map[string]int{"a": 1, "b": 2, "c": 3}
+		want := map[string]int{"a": 1, "b": 3, "d": 4}
+
+		result := be.MapContaining(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got does not contain want
+diff (-want +got):
+{
+	a: 1,
+-	b: 3,
++	b: 2,
+	c: 3,
+-	d: 4,
+}
+`))
+	})
+}
+
+func TestMapHasKey(t *testing.T) {
+	t.Run("has key", func(t *testing.T) {
+		g := ghost.New(t)
+
+		m := map[string]int{"a": 1, "b": 2}
+		key := "b"
+
+		result := be.MapHasKey(m, key)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `m has key key
+map: {
+	a: 1,
+>	b: 2,
+}
+key: b
+`))
+
+		result = be.MapHasKey(map[string]int{"a": 1}, "a")
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `map[string]int{"a": 1} has key "a"
+map: {
+>	a: 1,
+}
+key: a
+`))
+	})
+
+	t.Run("does not have key", func(t *testing.T) {
+		g := ghost.New(t)
+
+		m := map[string]int{"a": 1, "b": 2}
+		key := "c"
+
+		result := be.MapHasKey(m, key)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `m does not have key key
+map: {
+	a: 1,
+	b: 2,
+}
+key: c
+`))
+	})
+}
+
+func TestMapLacksKey(t *testing.T) {
+	t.Run("lacks key", func(t *testing.T) {
+		g := ghost.New(t)
+
+		m := map[int]string{1: "a", 2: "b"}
+		key := 3
+
+		result := be.MapLacksKey(m, key)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `m does not have key key
+map: {
+	1: a,
+	2: b,
+}
+key: 3
+`))
+	})
+
+	t.Run("has key", func(t *testing.T) {
+		g := ghost.New(t)
+
+		m := map[int]string{1: "a", 2: "b"}
+
+		result := be.MapLacksKey(m, 1)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `m has key 1
+map: {
+>	1: a,
+	2: b,
+}
+key: 1
+`))
+	})
+}
+
 func TestMapLen(t *testing.T) {
 	t.Run("equal <= 3", func(t *testing.T) {
 		g := ghost.New(t)
@@ -755,6 +1286,87 @@ element: 5
 	})
 }
 
+func TestSliceContainingAll(t *testing.T) {
+	t.Run("contains", func(t *testing.T) {
+		g := ghost.New(t)
+
+		slice := []int{1, 2, 3, 4}
+
+		result := be.SliceContainingAll(slice, 2, 4)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `slice contains all of 2, 4
+slice:    [
+	1
+>	2
+	3
+>	4
+]
+elements: [2 4]
+`))
+
+		elems := []int{1, 3}
+		result = be.SliceContainingAll([]int{1, 2, 3}, elems...)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `[]int{1, 2, 3} contains all of elems
+slice:    [1 2 3]
+elements: [1 3]
+`))
+	})
+
+	t.Run("does not contain", func(t *testing.T) {
+		g := ghost.New(t)
+
+		slice := []int{1, 2, 3, 4}
+
+		result := be.SliceContainingAll(slice, 2, 5, 6)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `slice does not contain all of 2, 5, 6
+slice:    [
+	1
+>	2
+	3
+	4
+]
+elements: [2 5 6]
+missing:  [5 6]
+`))
+	})
+}
+
+func TestSliceContainingNone(t *testing.T) {
+	t.Run("contains none", func(t *testing.T) {
+		g := ghost.New(t)
+
+		slice := []string{"a", "b"}
+
+		result := be.SliceContainingNone(slice, "c", "d")
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `slice contains none of "c", "d"
+slice:    [a b]
+elements: [c d]
+`))
+	})
+
+	t.Run("contains some", func(t *testing.T) {
+		g := ghost.New(t)
+
+		slice := []string{"a", "b", "c", "d"}
+
+		result := be.SliceContainingNone(slice, "b", "d", "e")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `slice contains some of "b", "d", "e"
+slice:    [
+	a
+>	b
+	c
+>	d
+]
+elements: [b d e]
+found:    [b d]
+`))
+	})
+}
+
 func TestSliceLen(t *testing.T) {
 	t.Run("equal <= 3", func(t *testing.T) {
 		g := ghost.New(t)
@@ -1035,3 +1647,205 @@ func TestZero(t *testing.T) {
 		g.Should(be.Equal(result.Message, "1 is non-zero"))
 	})
 }
+
+func TestOutputLimits(t *testing.T) {
+	t.Run("diff lines", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_DIFF_LINES", "3")
+
+		got := "a\nb\nc\nd\n"
+		want := "A\nB\nC\nD\n"
+
+		result := be.Equal(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got != want
+diff (-want +got):
+@@ -1,4 +1,4 @@
+-A
+-B
+... (6 more lines omitted)
+`))
+	})
+
+	t.Run("slice elements", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_ELEMENTS", "2")
+
+		got := []int{1, 2, 3, 4, 5}
+
+		result := be.SliceLen(got, 3)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got is length 5, not 3
+slice: [
+	1
+	2
+	... (3 more elements omitted)
+]
+`))
+	})
+
+	t.Run("map entries", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_ELEMENTS", "1")
+
+		got := map[string]int{"a": 1, "b": 2}
+
+		result := be.MapLen(got, 3)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got is length 2, not 3
+map: {
+	a: 1,
+	... (1 more entry omitted)
+}
+`))
+	})
+
+	t.Run("element matches", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_ELEMENTS", "1")
+
+		got := []int{1, 2}
+		want := []int{3, 4}
+
+		result := be.ElementsMatch(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(result.Message, `missing:
+	3
+	... (1 more element omitted)
+extra:
+	1
+	... (1 more element omitted)
+`))
+	})
+
+	t.Run("string length", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_STRING_LENGTH", "8")
+
+		got := "0123456789abcdefghij"
+		want := "0123456789abcdefghiJ"
+
+		result := be.Equal(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got != want
+got:  ..."cdefghij" ... (12 more characters omitted)
+                 ^
+want: ..."cdefghiJ" ... (12 more characters omitted)
+                 ^
+`))
+
+		result = be.Equal("abcdefghijkl", "xyz")
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(result.Message, `
+got:  "abcdefgh" ... (4 more characters omitted)
+want: "xyz"
+`))
+	})
+
+	t.Run("string subjects", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_STRING_LENGTH", "8")
+
+		result := be.StringContaining("abcdefghijkl", "xyz")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `"abcdefghijkl" does not contain "xyz"
+str:    "abcdefghijkl"
+substr: "xyz"
+`))
+
+		result = be.StringMatching("abcdefghijkl", "^x")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `"abcdefghijkl" does not match regular expression "^x"
+str:  "abcdefghijkl"
+expr: ^x
+`))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_ELEMENTS", "0")
+
+		got := make([]int, 200)
+
+		result := be.SliceLen(got, 3)
+		g.Should(be.False(result.Ok))
+		g.ShouldNot(be.StringContaining(result.Message, "omitted"))
+	})
+}
+
+func TestArtifacts(t *testing.T) {
+	t.Run("truncated diff", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_DIFF_LINES", "3")
+
+		got := "a\nb\nc\nd\n"
+		want := "A\nB\nC\nD\n"
+
+		result := be.Equal(got, want)
+		g.Should(be.False(result.Ok))
+		g.MustNot(be.Nil(result.Details))
+		g.Should(be.DeepEqual(result.Details.Artifacts, map[string]string{
+			"got.txt":  got,
+			"want.txt": want,
+			"diff.txt": `@@ -1,4 +1,4 @@
+-A
+-B
+-C
+-D
++a
++b
++c
++d
+`,
+		}))
+	})
+
+	t.Run("truncated value", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_DIFF_LINES", "3")
+
+		got := []int{1, 2, 3, 4}
+		want := []int{5, 6, 7, 8}
+
+		result := be.DeepEqual(got, want)
+		g.Should(be.False(result.Ok))
+		g.MustNot(be.Nil(result.Details))
+		g.Should(be.Equal(result.Details.Artifacts["got.txt"], "[1 2 3 4]\n"))
+		g.Should(be.Equal(result.Details.Artifacts["want.txt"], "[5 6 7 8]\n"))
+		g.Should(be.StringContaining(result.Details.Artifacts["diff.txt"], "1, 2, 3, 4,"))
+	})
+
+	t.Run("truncated json", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_DIFF_LINES", "1")
+
+		got := `{"a": 1, "b": 2}`
+		want := `{"a": 3, "b": 4}`
+
+		result := be.JSONEqual(got, want)
+		g.Should(be.False(result.Ok))
+		g.MustNot(be.Nil(result.Details))
+		g.Should(be.Equal(result.Details.Artifacts["got.json"], got))
+		g.Should(be.Equal(result.Details.Artifacts["want.json"], want))
+		g.Should(be.StringContaining(result.Details.Artifacts["diff.txt"], `"a"`))
+	})
+
+	t.Run("not truncated", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.Equal("a\nb\n", "A\nB\n")
+		g.Should(be.False(result.Ok))
+		g.MustNot(be.Nil(result.Details))
+		g.Should(be.Nil(result.Details.Artifacts))
+	})
+}
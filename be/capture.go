@@ -0,0 +1,34 @@
+package be
+
+// Captures holds the values recorded by [Capture] for a single assertion.
+//
+// The zero value is ready to use.
+type Captures struct {
+	values []any
+}
+
+// Capture records the value of a sub-expression in an assertion to c,
+// returning the value unchanged.
+//
+// When c is also passed to [True] or [False], the captured values are drawn
+// in a diagram beneath the expression, along with the values of any
+// comparisons or logical operations that can be computed from them:
+//
+//	var c be.Captures
+//	g.Should(be.True(be.Capture(&c, len(items)) > 3 && be.Capture(&c, user.Active), &c))
+func Capture[T any](c *Captures, v T) T {
+	c.values = append(c.values, v)
+	return v
+}
+
+// take returns and clears the values recorded to the first of the captures,
+// so that the captures can be reused by a later assertion.
+func take(captures []*Captures) []any {
+	if len(captures) == 0 || captures[0] == nil {
+		return nil
+	}
+
+	values := captures[0].values
+	captures[0].values = nil
+	return values
+}
@@ -0,0 +1,123 @@
+package be_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+func TestCapture(t *testing.T) {
+	t.Run("value", func(t *testing.T) {
+		g := ghost.New(t)
+
+		var c be.Captures
+		g.Should(be.Equal(be.Capture(&c, 3), 3))
+		g.Should(be.Equal(be.Capture(&c, "foo"), "foo"))
+	})
+
+	t.Run("true", func(t *testing.T) {
+		g := ghost.New(t)
+
+		items := []int{1, 2}
+		name := "bob"
+
+		var c be.Captures
+		result := be.True(be.Capture(&c, len(items)) > 1 && be.Capture(&c, name) == "alice", &c)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `len(items) > 1 && name == "alice" is false
+|          |   |  |    |
+|          |   |  |    false
+|          |   |  "bob"
+|          |   false
+|          true
+2`))
+	})
+
+	t.Run("false", func(t *testing.T) {
+		g := ghost.New(t)
+
+		a, b := 2, 3
+
+		var c be.Captures
+		result := be.False(be.Capture(&c, a)+be.Capture(&c, b) == 5, &c)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `a+b == 5 is true
+||| |
+||| true
+||3
+|5
+2`))
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		g := ghost.New(t)
+
+		count := 2
+		active := true
+
+		var c be.Captures
+		result := be.True(be.Capture(&c, count) > 3 && be.Capture(&c, active), &c)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `count > 3 && active is false
+|     |   |
+|     |   false
+|     false
+2`))
+	})
+
+	t.Run("multiline", func(t *testing.T) {
+		g := ghost.New(t)
+
+		count := 2
+		active := false
+
+		var c be.Captures
+		result := be.True(be.Capture(&c, count) > 1 &&
+			be.Capture(&c, active), &c)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `count > 1 && active is false
+|     |   |  |
+|     |   |  false
+|     |   false
+|     true
+2`))
+	})
+
+	t.Run("reused", func(t *testing.T) {
+		g := ghost.New(t)
+
+		x, a := 5, 1
+
+		var c be.Captures
+		g.Should(be.True(be.Capture(&c, x) == 5, &c))
+
+		result := be.True(be.Capture(&c, a) > 2, &c)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `a > 2 is false
+| |
+| false
+1`))
+	})
+
+	t.Run("not passed", func(t *testing.T) {
+		g := ghost.New(t)
+
+		count := 2
+
+		var c be.Captures
+		result := be.True(be.Capture(&c, count) > 3)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, "be.Capture(&c, count) > 3 is false"))
+	})
+
+	t.Run("no captures", func(t *testing.T) {
+		g := ghost.New(t)
+
+		count := 2
+
+		result := be.True(count > 3)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, "count > 3 is false"))
+	})
+}
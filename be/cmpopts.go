@@ -0,0 +1,79 @@
+package be
+
+import (
+	"go/token"
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// IgnoreUnexported is a [cmp.Option] for [DeepEqualWith] that ignores every
+// unexported struct field.
+func IgnoreUnexported() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		sf, ok := p.Last().(cmp.StructField)
+		return ok && !token.IsExported(sf.Name())
+	}, cmp.Ignore())
+}
+
+// IgnoreZeroFields is a [cmp.Option] for [DeepEqualWith] that ignores any
+// struct field set to its zero value in the wanted value, so that only the
+// fields which are set are compared.
+func IgnoreZeroFields() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		sf, ok := p.Last().(cmp.StructField)
+		if !ok {
+			return false
+		}
+
+		// Diffs are computed from want to got, so want is always on the left.
+		want, _ := sf.Values()
+		return want.IsValid() && want.IsZero()
+	}, cmp.Ignore())
+}
+
+// IgnoreSliceOrder is a [cmp.Option] for [DeepEqualWith] that compares every
+// slice of booleans, numbers, or strings without regard to the order of its
+// elements.
+//
+// Slices of any other element type are still compared in order. To ignore the
+// order of those, use [cmpopts.SortSlices] with a function that orders them.
+func IgnoreSliceOrder() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		t := p.Last().Type()
+		return t != nil && t.Kind() == reflect.Slice && isOrdered(t.Elem().Kind())
+	}, cmpopts.SortSlices(func(a, b any) bool {
+		return lessOrdered(reflect.ValueOf(a), reflect.ValueOf(b))
+	}))
+}
+
+// isOrdered reports whether values of a kind can be ordered by [lessOrdered].
+func isOrdered(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// lessOrdered reports whether a sorts before b. Both must be of the same kind,
+// which must be one for which isOrdered is true.
+func lessOrdered(a, b reflect.Value) bool {
+	switch {
+	case a.CanInt():
+		return a.Int() < b.Int()
+	case a.CanUint():
+		return a.Uint() < b.Uint()
+	case a.CanFloat():
+		return a.Float() < b.Float()
+	case a.Kind() == reflect.Bool:
+		return !a.Bool() && b.Bool()
+	default:
+		return a.String() < b.String()
+	}
+}
@@ -1,7 +1,10 @@
 package be
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"math/rand"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,6 +18,7 @@ import (
 func All(results ...ghost.Result) ghost.Result {
 	args := ghostlib.ArgsFromAST(results)
 	return applyVariadicBooleanLogic(
+		"be.All",
 		true,
 		func(acc, val bool) bool {
 			return acc && val
@@ -28,6 +32,7 @@ func All(results ...ghost.Result) ghost.Result {
 func Any(results ...ghost.Result) ghost.Result {
 	args := ghostlib.ArgsFromAST(results)
 	return applyVariadicBooleanLogic(
+		"be.Any",
 		false,
 		func(acc, val bool) bool {
 			return acc || val
@@ -38,19 +43,23 @@ func Any(results ...ghost.Result) ghost.Result {
 }
 
 func applyVariadicBooleanLogic(
+	name string,
 	initial bool,
 	apply func(acc, val bool) bool,
 	results []ghost.Result,
 	args []string,
 ) ghost.Result {
+	details := &ghost.Details{Name: name, Args: args, Children: results}
+
 	if len(results) == 0 {
 		return ghost.Result{
 			Ok:      initial,
 			Message: "no assertions were provided",
+			Details: details,
 		}
 	}
 
-	out := ghost.Result{Ok: initial}
+	out := ghost.Result{Ok: initial, Details: details}
 	for i, result := range results {
 		out.Ok = apply(out.Ok, result.Ok)
 
@@ -86,6 +95,60 @@ func indentString(s string) string {
 	return s
 }
 
+// Consistently asserts that a function returns an Ok [ghost.Result] every
+// time it is run within a duration.
+func Consistently(
+	f func() ghost.Result,
+	duration time.Duration,
+	interval time.Duration,
+) ghost.Result {
+	args := ghostlib.ArgsFromAST(f, duration, interval)
+	argF := args[0]
+
+	details := &ghost.Details{Name: "be.Consistently", Args: args}
+
+	outcome := poll(f, duration, interval, func(result ghost.Result) bool {
+		return !result.Ok
+	})
+
+	switch {
+	case outcome.samples == 0:
+		return ghost.Result{
+			Ok:      false,
+			Message: fmt.Sprintf("%s did not return value within %s", argF, duration),
+			Details: details,
+		}
+	case outcome.done:
+		details.Children = []ghost.Result{outcome.result}
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%s did not stay ok for %s
+passed: %d samples
+failed: sample %d at %s
+	%s`,
+				argF, duration,
+				outcome.samples-1,
+				outcome.samples, outcome.elapsed,
+				indentString(outcome.result.Message),
+			),
+			Details: details,
+		}
+	}
+
+	details.Children = []ghost.Result{outcome.result}
+	return ghost.Result{
+		Ok: true,
+		Message: fmt.Sprintf(`%s stayed ok for %s
+passed: %d samples
+	%s`,
+			argF, duration,
+			outcome.samples,
+			indentString(outcome.result.Message),
+		),
+		Details: details,
+	}
+}
+
 // Eventually asserts that a function eventually returns an Ok [ghost.Result].
 func Eventually(
 	f func() ghost.Result,
@@ -95,29 +158,322 @@ func Eventually(
 	args := ghostlib.ArgsFromAST(f, timeout, interval)
 	argF := args[0]
 
+	outcome := poll(f, timeout, interval, func(result ghost.Result) bool {
+		return result.Ok
+	})
+
+	if outcome.samples == 0 {
+		return ghost.Result{
+			Ok:      false,
+			Message: fmt.Sprintf("%s did not return value within %s timeout", argF, timeout),
+			Details: &ghost.Details{Name: "be.Eventually", Args: args},
+		}
+	}
+
+	return outcome.result
+}
+
+// EventuallyContext asserts that a function eventually returns an Ok
+// [ghost.Result] before a context is done.
+//
+// The function is passed a context which is cancelled once the assertion
+// returns, so that a function which is still running can stop. Between
+// attempts, EventuallyContext waits according to the [Backoff].
+//
+// To stop shortly before the test times out, use [DeadlineContext].
+func EventuallyContext(
+	ctx context.Context,
+	f func(ctx context.Context) ghost.Result,
+	backoff Backoff,
+) ghost.Result {
+	args := ghostlib.ArgsFromAST(ctx, f, backoff)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	ch := make(chan ghost.Result, 1)
+	go func() { ch <- f(ctx) }()
+
+	var (
+		wait     <-chan time.Time
+		attempts int
+		failures []ghost.Result
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			elapsed := time.Since(start)
+			return eventuallyContextFailure(args, ctx.Err(), attempts, elapsed, failures)
+		case <-wait:
+			wait = nil
+			go func() { ch <- f(ctx) }()
+		case result := <-ch:
+			attempts++
+			if result.Ok {
+				return result
+			}
+
+			failures = appendDistinct(failures, result)
+			timer.Reset(backoff.delay(attempts))
+			wait = timer.C
+		}
+	}
+}
+
+// maxDistinctFailures is the number of distinct failures reported by
+// [EventuallyContext].
+const maxDistinctFailures = 3
+
+// appendDistinct adds a result to a list of the most recent failures, keeping
+// only the last of any results with the same message.
+func appendDistinct(failures []ghost.Result, result ghost.Result) []ghost.Result {
+	out := make([]ghost.Result, 0, len(failures)+1)
+	for _, f := range failures {
+		if f.Message != result.Message {
+			out = append(out, f)
+		}
+	}
+	out = append(out, result)
+
+	if len(out) > maxDistinctFailures {
+		out = out[len(out)-maxDistinctFailures:]
+	}
+
+	return out
+}
+
+func eventuallyContextFailure(
+	args []string,
+	err error,
+	attempts int,
+	elapsed time.Duration,
+	failures []ghost.Result,
+) ghost.Result {
+	argF := args[1]
+
+	details := &ghost.Details{Name: "be.EventuallyContext", Args: args, Children: failures}
+
+	if attempts == 0 {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%s did not return value before context was done: %v
+elapsed: %s`,
+				argF, err,
+				elapsed.Round(time.Microsecond),
+			),
+			Details: details,
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `%s did not return an ok result before context was done: %v
+attempts: %d
+elapsed:  %s
+last %d distinct failures:`,
+		argF, err,
+		attempts,
+		elapsed.Round(time.Microsecond),
+		len(failures),
+	)
+
+	for i, f := range failures {
+		if i != 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("\n\t")
+		b.WriteString(indentString(f.Message))
+	}
+
+	return ghost.Result{
+		Ok:      false,
+		Message: b.String(),
+		Details: details,
+	}
+}
+
+// A Backoff controls how long [EventuallyContext] waits between attempts.
+//
+// The zero value waits 10ms between every attempt.
+type Backoff struct {
+	// Initial is the time to wait after the first attempt.
+	Initial time.Duration
+
+	// Max is the longest time to wait between attempts. If zero, there is no
+	// maximum.
+	Max time.Duration
+
+	// Multiplier scales the time to wait after each attempt. Values less than 1
+	// are treated as 1, for a constant interval.
+	Multiplier float64
+
+	// Jitter randomly adjusts each wait by up to this fraction of itself, in
+	// either direction, to avoid retrying in lockstep. It is applied after Max.
+	Jitter float64
+}
+
+// ExponentialBackoff returns a [Backoff] that doubles the time to wait after
+// each attempt, up to a maximum, with a small amount of jitter.
+func ExponentialBackoff(initial, limit time.Duration) Backoff {
+	return Backoff{
+		Initial:    initial,
+		Max:        limit,
+		Multiplier: 2,
+		Jitter:     0.1,
+	}
+}
+
+// delay returns the time to wait after an attempt, counting from 1.
+func (b Backoff) delay(attempt int) time.Duration {
+	d := float64(b.Initial)
+	if d <= 0 {
+		d = float64(10 * time.Millisecond)
+	}
+
+	if b.Multiplier > 1 {
+		d *= math.Pow(b.Multiplier, float64(attempt-1))
+	}
+
+	if b.Max > 0 && d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+
+	if b.Jitter > 0 {
+		d += d * b.Jitter * (2*rand.Float64() - 1) //nolint:gosec // not for security
+	}
+
+	return time.Duration(d)
+}
+
+// DeadlineContext returns a context that is done shortly before a test's
+// deadline, as set by the -timeout flag, leaving time for failures to be
+// reported. If the test has no deadline, the context is never done unless
+// cancelled.
+//
+//	ctx, cancel := be.DeadlineContext(t)
+//	defer cancel()
+func DeadlineContext(
+	t interface{ Deadline() (time.Time, bool) },
+) (context.Context, context.CancelFunc) {
+	deadline, ok := t.Deadline()
+	if !ok {
+		return context.WithCancel(context.Background())
+	}
+
+	grace := time.Until(deadline) / 20
+	if grace > 5*time.Second {
+		grace = 5 * time.Second
+	}
+
+	return context.WithDeadline(context.Background(), deadline.Add(-grace))
+}
+
+// Never asserts that a function never returns an Ok [ghost.Result] when run
+// within a duration.
+func Never(
+	f func() ghost.Result,
+	duration time.Duration,
+	interval time.Duration,
+) ghost.Result {
+	args := ghostlib.ArgsFromAST(f, duration, interval)
+	argF := args[0]
+
+	details := &ghost.Details{Name: "be.Never", Args: args}
+
+	outcome := poll(f, duration, interval, func(result ghost.Result) bool {
+		return result.Ok
+	})
+
+	switch {
+	case outcome.samples == 0:
+		return ghost.Result{
+			Ok:      false,
+			Message: fmt.Sprintf("%s did not return value within %s", argF, duration),
+			Details: details,
+		}
+	case outcome.done:
+		details.Children = []ghost.Result{outcome.result}
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%s returned an ok result within %s
+passed: %d samples
+failed: sample %d at %s
+	%s`,
+				argF, duration,
+				outcome.samples-1,
+				outcome.samples, outcome.elapsed,
+				indentString(outcome.result.Message),
+			),
+			Details: details,
+		}
+	}
+
+	details.Children = []ghost.Result{outcome.result}
+	return ghost.Result{
+		Ok: true,
+		Message: fmt.Sprintf(`%s never returned an ok result within %s
+passed: %d samples`,
+			argF, duration,
+			outcome.samples,
+		),
+		Details: details,
+	}
+}
+
+// pollOutcome describes the results of polling a function.
+type pollOutcome struct {
+	// result is the last result returned.
+	result ghost.Result
+	// samples is the number of results returned.
+	samples int
+	// elapsed is the time between starting and the last result being returned.
+	elapsed time.Duration
+	// done is whether polling stopped before the timeout.
+	done bool
+}
+
+// poll runs a function at each interval until it returns a result for which
+// done returns true, or until the timeout elapses.
+//
+// The function is never run concurrently with itself. If it is still running
+// once the timeout elapses, its result is discarded.
+func poll(
+	f func() ghost.Result,
+	timeout time.Duration,
+	interval time.Duration,
+	done func(ghost.Result) bool,
+) pollOutcome {
+	start := time.Now()
+
 	timer := time.NewTimer(timeout)
 	defer timer.Stop()
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	lastRun := ghost.Result{
-		Ok:      false,
-		Message: fmt.Sprintf("%s did not return value within %s timeout", argF, timeout),
-	}
+	var outcome pollOutcome
 
 	ch := make(chan ghost.Result, 1)
 
 	for tick := ticker.C; ; {
 		select {
 		case <-timer.C:
-			return lastRun
+			return outcome
 		case <-tick:
 			tick = nil
 			go func() { ch <- f() }()
-		case lastRun = <-ch:
-			if lastRun.Ok {
-				return lastRun
+		case outcome.result = <-ch:
+			outcome.samples++
+			outcome.elapsed = time.Since(start).Round(time.Microsecond)
+			if done(outcome.result) {
+				outcome.done = true
+				return outcome
 			}
 			tick = ticker.C
 		}
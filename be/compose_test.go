@@ -1,6 +1,7 @@
 package be_test
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
@@ -101,6 +102,19 @@ assertion %s is true
 			),
 		))
 	})
+
+	t.Run("details", func(t *testing.T) {
+		g := ghost.New(t)
+
+		first := be.Equal(1, 1)
+		second := be.Equal(1, 2)
+
+		result := be.All(first, second)
+		g.Must(be.Not(be.Nil(result.Details)))
+		g.Should(be.Equal(result.Details.Name, "be.All"))
+		g.Should(be.DeepEqual(result.Details.Args, []string{"first", "second"}))
+		g.Should(be.DeepEqual(result.Details.Children, []ghost.Result{first, second}))
+	})
 }
 
 func TestAny(t *testing.T) {
@@ -200,6 +214,57 @@ assertion %s is false
 	})
 }
 
+func TestConsistently(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		count := 0
+		result := be.Consistently(func() ghost.Result {
+			count++
+			return be.Greater(count, 0)
+		}, 50*time.Millisecond, 5*time.Millisecond)
+
+		g.Should(be.True(result.Ok))
+		g.Should(be.StringMatching(result.Message, `^func\(\) ghost.Result {
+	count\+\+
+	return be.Greater\(count, 0\)
+} stayed ok for 50ms
+passed: \d+ samples
+	count \(\d+\) is greater than 0$`))
+	})
+
+	t.Run("not ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		count := 0
+		result := be.Consistently(func() ghost.Result {
+			count++
+			return be.Less(count, 3)
+		}, 100*time.Millisecond, 5*time.Millisecond)
+
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringMatching(result.Message, `^func\(\) ghost.Result {
+	count\+\+
+	return be.Less\(count, 3\)
+} did not stay ok for 100ms
+passed: 2 samples
+failed: sample 3 at \S+
+	count \(3\) is not less than 3$`))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.Consistently(func() ghost.Result {
+			time.Sleep(100 * time.Millisecond)
+			return be.True(true)
+		}, 10*time.Millisecond, 5*time.Millisecond)
+
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(result.Message, "did not return value within 10ms"))
+	})
+}
+
 func TestEventually(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		g := ghost.New(t)
@@ -249,6 +314,173 @@ want: -1
 	})
 }
 
+func TestEventuallyContext(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		count := 0
+		result := be.EventuallyContext(ctx, func(context.Context) ghost.Result {
+			count++
+			return be.Equal(count, 3)
+		}, be.ExponentialBackoff(time.Millisecond, 5*time.Millisecond))
+
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `count == 3`))
+	})
+
+	t.Run("not ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		count := 0
+		result := be.EventuallyContext(ctx, func(context.Context) ghost.Result {
+			count++
+			return be.Equal(count%4, -1)
+		}, be.Backoff{Initial: time.Millisecond})
+
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringMatching(result.Message, `^func\(context.Context\) ghost.Result {
+	count\+\+
+	return be.Equal\(count%4, -1\)
+} did not return an ok result before context was done: context deadline exceeded
+attempts: \d+
+elapsed:  \S+
+last 3 distinct failures:
+	count % 4 != -1
+	got:  \d
+	want: -1
+
+	count % 4 != -1
+	got:  \d
+	want: -1
+
+	count % 4 != -1
+	got:  \d
+	want: -1$`))
+
+		g.Must(be.Not(be.Nil(result.Details)))
+		g.Should(be.SliceLen(result.Details.Children, 3))
+	})
+
+	t.Run("cancelled while running", func(t *testing.T) {
+		g := ghost.New(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		stopped := make(chan struct{})
+		result := be.EventuallyContext(ctx, func(ctx context.Context) ghost.Result {
+			<-ctx.Done()
+			close(stopped)
+			return be.True(true)
+		}, be.Backoff{})
+
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(
+			result.Message,
+			"did not return value before context was done: context deadline exceeded",
+		))
+
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("polled function was not cancelled")
+		}
+	})
+}
+
+func TestDeadlineContext(t *testing.T) {
+	t.Run("deadline", func(t *testing.T) {
+		g := ghost.New(t)
+
+		deadline := time.Now().Add(time.Minute)
+
+		ctx, cancel := be.DeadlineContext(deadliner{deadline: deadline, ok: true})
+		defer cancel()
+
+		got, ok := ctx.Deadline()
+		g.Must(be.True(ok))
+		g.Should(be.True(got.Before(deadline)))
+		g.Should(be.True(got.After(deadline.Add(-5 * time.Second))))
+	})
+
+	t.Run("no deadline", func(t *testing.T) {
+		g := ghost.New(t)
+
+		ctx, cancel := be.DeadlineContext(deadliner{})
+
+		_, ok := ctx.Deadline()
+		g.Should(be.False(ok))
+
+		cancel()
+		g.Should(be.ErrorIs(ctx.Err(), context.Canceled))
+	})
+}
+
+type deadliner struct {
+	deadline time.Time
+	ok       bool
+}
+
+func (d deadliner) Deadline() (time.Time, bool) {
+	return d.deadline, d.ok
+}
+
+func TestNever(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		count := 0
+		result := be.Never(func() ghost.Result {
+			count++
+			return be.Less(count, 0)
+		}, 50*time.Millisecond, 5*time.Millisecond)
+
+		g.Should(be.True(result.Ok))
+		g.Should(be.StringMatching(result.Message, `^func\(\) ghost.Result {
+	count\+\+
+	return be.Less\(count, 0\)
+} never returned an ok result within 50ms
+passed: \d+ samples$`))
+	})
+
+	t.Run("not ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		count := 0
+		result := be.Never(func() ghost.Result {
+			count++
+			return be.Equal(count, 2)
+		}, 100*time.Millisecond, 5*time.Millisecond)
+
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringMatching(result.Message, `^func\(\) ghost.Result {
+	count\+\+
+	return be.Equal\(count, 2\)
+} returned an ok result within 100ms
+passed: 1 samples
+failed: sample 2 at \S+
+	count == 2$`))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.Never(func() ghost.Result {
+			time.Sleep(100 * time.Millisecond)
+			return be.True(false)
+		}, 10*time.Millisecond, 5*time.Millisecond)
+
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(result.Message, "did not return value within 10ms"))
+	})
+}
+
 func TestNot(t *testing.T) {
 	g := ghost.New(t)
 
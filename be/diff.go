@@ -2,39 +2,328 @@ package be
 
 import (
 	"fmt"
+	"os"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/text/unicode/norm"
 
 	"github.com/rliebz/ghost/internal/color"
 	"github.com/rliebz/ghost/internal/jsondiff"
+	"github.com/rliebz/ghost/internal/limits"
+	"github.com/rliebz/ghost/internal/textdiff"
 )
 
 var exportTypes = cmp.Exporter(func(reflect.Type) bool { return true })
 
 func colorDiff[T any](x, y T, opts ...cmp.Option) string {
-	diff := cmp.Diff(x, y, append(opts, exportTypes)...)
-	return applyColors(diff)
+	return applyColors(cmpDiff(x, y, opts...))
 }
 
-func colorJSONDiff[T ~string | ~[]byte](got, want T) (string, jsondiff.Kind) {
-	diff, kind := jsondiff.Diff(got, want)
+// cmpDiff returns the diff of two values without colors or limits.
+func cmpDiff[T any](x, y T, opts ...cmp.Option) string {
+	// Copy the options, so the caller's slice is never appended to.
+	opts = append(append([]cmp.Option{}, opts...), exportTypes)
+	return cmp.Diff(x, y, opts...)
+}
+
+// stringDiff describes the difference between two strings, using a line diff
+// if either of them spans multiple lines.
+func stringDiff(got, want string) string {
+	if strings.Contains(got, "\n") || strings.Contains(want, "\n") {
+		return lineDiff(got, want)
+	}
+
+	return inlineDiff(got, want)
+}
+
+// defaultDiffContext is the number of unchanged lines shown around each
+// change in a line diff.
+const defaultDiffContext = 3
+
+// lineDiff returns a unified diff of the lines of two strings.
+func lineDiff(got, want string) string {
+	return applyColors(textdiff.Unified(want, got, diffContext()))
+}
+
+// diffContext returns the number of unchanged lines to show around each
+// change in a line diff, which can be set with GHOST_DIFF_CONTEXT.
+func diffContext() int {
+	n, err := strconv.Atoi(os.Getenv("GHOST_DIFF_CONTEXT"))
+	if err != nil || n < 0 {
+		return defaultDiffContext
+	}
+	return n
+}
+
+// inlineDiff shows two single-line strings, highlighting the runs of
+// characters that differ if the strings are similar enough for that to help,
+// or if the difference is hard to see.
+func inlineDiff(got, want string) string {
+	gotLine, wantLine := quoteLimited(got), quoteLimited(want)
+	var gotMarker, wantMarker string
+
+	notes := invisibleDifferences(got, want)
+
+	if utf8.ValidString(got) && utf8.ValidString(want) {
+		gotRunes, wantRunes := []rune(got), []rune(want)
+		ops := textdiff.Diff(wantRunes, gotRunes)
+
+		if len(notes) > 0 || similar(ops, len(gotRunes), len(wantRunes)) {
+			gotChanged, wantChanged := changedRunes(ops)
+			start := windowStart(ops, len(gotRunes), len(wantRunes))
+			gotLine, gotMarker = highlightWindow(gotRunes, gotChanged, start, color.Green)
+			wantLine, wantMarker = highlightWindow(wantRunes, wantChanged, start, color.Red)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "got:  %v\n", gotLine)
+	if gotMarker != "" {
+		fmt.Fprintf(&sb, "      %s\n", gotMarker)
+	}
+	fmt.Fprintf(&sb, "want: %v\n", wantLine)
+	if wantMarker != "" {
+		fmt.Fprintf(&sb, "      %s\n", wantMarker)
+	}
+	for _, note := range notes {
+		fmt.Fprintf(&sb, "note: %s\n", note)
+	}
+
+	return sb.String()
+}
+
+// quoteLimited quotes a string like quoteString, cutting it short if it is
+// longer than the limit.
+func quoteLimited(s string) string {
+	s, omitted := limitString(s)
+	if omitted == 0 {
+		return quoteString(s)
+	}
+
+	return quoteString(s) + " " + limits.Omitted(omitted, "character", "characters")
+}
+
+// limitString cuts a string short if it is longer than the limit, returning
+// how many characters were left out.
+func limitString(s string) (string, int) {
+	limit := limits.StringLength()
+	if !limits.Exceeded(utf8.RuneCountInString(s), limit) {
+		return s, 0
+	}
+
+	rs := []rune(s)
+	return string(rs[:limit]), len(rs) - limit
+}
+
+// similar reports whether more than half of the longer string is unchanged.
+func similar(ops []textdiff.Op, gotLen, wantLen int) bool {
+	var equal int
+	for _, op := range ops {
+		if op == textdiff.Equal {
+			equal++
+		}
+	}
+
+	longest := gotLen
+	if wantLen > longest {
+		longest = wantLen
+	}
+
+	return equal*2 > longest
+}
+
+// changedRunes converts an edit script from want to got into which runes of
+// each string are not shared by the other.
+func changedRunes(ops []textdiff.Op) (gotChanged, wantChanged []bool) {
+	for _, op := range ops {
+		switch op {
+		case textdiff.Equal:
+			gotChanged = append(gotChanged, false)
+			wantChanged = append(wantChanged, false)
+		case textdiff.Delete:
+			wantChanged = append(wantChanged, true)
+		case textdiff.Insert:
+			gotChanged = append(gotChanged, true)
+		}
+	}
+	return gotChanged, wantChanged
+}
+
+// windowStart returns where to start showing strings that are too long to
+// show in full, so that the first difference is shown with a little of what
+// comes before it.
+func windowStart(ops []textdiff.Op, gotLen, wantLen int) int {
+	limit := limits.StringLength()
+	if !limits.Exceeded(gotLen, limit) && !limits.Exceeded(wantLen, limit) {
+		return 0
+	}
+
+	prefix := 0
+	for prefix < len(ops) && ops[prefix] == textdiff.Equal {
+		prefix++
+	}
+
+	// Fill the window if the difference is near the end of the strings.
+	shortest := gotLen
+	if wantLen < shortest {
+		shortest = wantLen
+	}
+
+	start := prefix - limit/4
+	if start > shortest-limit {
+		start = shortest - limit
+	}
+
+	if start < 0 {
+		return 0
+	}
+	return start
+}
+
+// highlightWindow highlights the part of a string that fits within the string
+// length limit, starting from start.
+func highlightWindow(
+	rs []rune,
+	changed []bool,
+	start int,
+	paint func(string) string,
+) (line, marker string) {
+	end := len(rs)
+	if limit := limits.StringLength(); limits.Exceeded(end-start, limit) {
+		end = start + limit
+	}
+
+	line, marker = highlight(rs[start:end], changed[start:end], paint)
+
+	if start > 0 {
+		line = "..." + line
+		if marker != "" {
+			marker = "   " + marker
+		}
+	}
+
+	if omitted := start + len(rs) - end; omitted > 0 {
+		line += " " + limits.Omitted(omitted, "character", "characters")
+	}
+
+	return line, marker
+}
+
+// highlight quotes a string, painting the changed runs of runes. If color is
+// disabled, a line marking the changed runs with carets is returned as well.
+func highlight(rs []rune, changed []bool, paint func(string) string) (line, marker string) {
+	var lineSB, markerSB strings.Builder
+	lineSB.WriteByte('"')
+	markerSB.WriteByte(' ')
+
+	for start := 0; start < len(rs); {
+		end := start + 1
+		for end < len(rs) && changed[end] == changed[start] {
+			end++
+		}
+
+		quoted := strconv.Quote(string(rs[start:end]))
+		quoted = quoted[1 : len(quoted)-1]
+		width := utf8.RuneCountInString(quoted)
+
+		if changed[start] {
+			lineSB.WriteString(paint(quoted))
+			markerSB.WriteString(strings.Repeat("^", width))
+		} else {
+			lineSB.WriteString(quoted)
+			markerSB.WriteString(strings.Repeat(" ", width))
+		}
+
+		start = end
+	}
+
+	lineSB.WriteByte('"')
+
+	if color.Enabled() {
+		return lineSB.String(), ""
+	}
+	return lineSB.String(), strings.TrimRight(markerSB.String(), " ")
+}
+
+// zeroWidth removes characters that take up no space when printed.
+var zeroWidth = strings.NewReplacer(
+	"\u200b", "", // zero width space
+	"\u200c", "", // zero width non-joiner
+	"\u200d", "", // zero width joiner
+	"\u2060", "", // word joiner
+	"\ufeff", "", // zero width no-break space
+)
+
+// blanks matches runs of spaces and tabs.
+var blanks = regexp.MustCompile(`[ \t]+`)
+
+// invisibleDifferences explains differences between two strings that are
+// hard to spot when they are printed.
+func invisibleDifferences(got, want string) []string {
+	switch {
+	case strings.TrimRightFunc(got, unicode.IsSpace) == strings.TrimRightFunc(want, unicode.IsSpace):
+		return []string{"got and want differ only in trailing whitespace"}
+	case strings.Contains(got, "\t") != strings.Contains(want, "\t") &&
+		blanks.ReplaceAllString(got, " ") == blanks.ReplaceAllString(want, " "):
+		return []string{"got and want differ only in tabs and spaces"}
+	case norm.NFC.String(got) == norm.NFC.String(want):
+		return []string{"got and want are equal after Unicode normalization"}
+	case zeroWidth.Replace(got) == zeroWidth.Replace(want):
+		return []string{"got and want differ only in zero-width characters"}
+	}
+
+	var notes []string
+	if zeroWidth.Replace(got) != got {
+		notes = append(notes, "got contains zero-width characters")
+	}
+	if zeroWidth.Replace(want) != want {
+		notes = append(notes, "want contains zero-width characters")
+	}
+	return notes
+}
+
+func colorDecodedDiff[T ~string | ~[]byte](
+	got T,
+	want T,
+	f format,
+	opts jsondiff.Options,
+) (string, jsondiff.Kind) {
+	diff, kind := decodedDiff(got, want, f, opts)
 	return applyColors(diff), kind
 }
 
+// decodedDiff returns the diff of two encoded inputs without limiting its
+// lines.
+func decodedDiff[T ~string | ~[]byte](
+	got T,
+	want T,
+	f format,
+	opts jsondiff.Options,
+) (string, jsondiff.Kind) {
+	opts.MaxDifferences = limits.Elements()
+	return jsondiff.DiffDecoded(got, want, f.decode, opts)
+}
+
 func applyColors(diff string) string {
 	if diff == "" {
 		return ""
 	}
 
-	ss := strings.Split(diff, "\n")
+	ss := strings.Split(limitLines(diff), "\n")
 	for i, s := range ss {
 		switch {
 		case strings.HasPrefix(s, "-"):
 			ss[i] = color.Red(s)
 		case strings.HasPrefix(s, "+"):
 			ss[i] = color.Green(s)
+		case strings.HasPrefix(s, "@@"):
+			ss[i] = color.Yellow(s)
 		// Only color the first character, since we expect inline red/green
 		case strings.HasPrefix(s, "~"):
 			ss[i] = color.Yellow("~") + s[1:]
@@ -49,3 +338,78 @@ func applyColors(diff string) string {
 		strings.Join(ss, "\n"),
 	)
 }
+
+// limitLines cuts a diff short if it has more lines than the limit.
+func limitLines(diff string) string {
+	lines := splitLines(diff)
+
+	limit := limits.Lines()
+	if !limits.Exceeded(len(lines), limit) {
+		return diff
+	}
+
+	return strings.Join(lines[:limit], "") +
+		limits.Omitted(len(lines)-limit, "line", "lines") + "\n"
+}
+
+// splitLines splits a string into lines, keeping the line endings.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// valueArtifacts returns the complete got, want, and diff of two values if
+// the diff is too long to show in full.
+func valueArtifacts[T any](got, want T, diff string) map[string]string {
+	if !limits.Exceeded(len(splitLines(diff)), limits.Lines()) {
+		return nil
+	}
+
+	return artifacts(".txt", fmt.Sprintf("%+v\n", got), fmt.Sprintf("%+v\n", want), diff)
+}
+
+// stringArtifacts returns the complete got, want, and diff of two strings if
+// they are too long to show in full.
+func stringArtifacts(got, want string) map[string]string {
+	if strings.Contains(got, "\n") || strings.Contains(want, "\n") {
+		diff := textdiff.Unified(want, got, diffContext())
+		if !limits.Exceeded(len(splitLines(diff)), limits.Lines()) {
+			return nil
+		}
+		return artifacts(".txt", got, want, diff)
+	}
+
+	limit := limits.StringLength()
+	if !limits.Exceeded(utf8.RuneCountInString(got), limit) &&
+		!limits.Exceeded(utf8.RuneCountInString(want), limit) {
+		return nil
+	}
+
+	return artifacts(".txt", got, want, "")
+}
+
+// decodedArtifacts returns the complete got, want, and diff of two encoded
+// inputs if the diff is too long to show in full.
+func decodedArtifacts(f format, got, want, diff string) map[string]string {
+	if !limits.Exceeded(len(splitLines(diff)), limits.Lines()) {
+		return nil
+	}
+
+	return artifacts("."+strings.ToLower(f.name), got, want, diff)
+}
+
+// artifacts names the complete output of a failure for ghost.Details, leaving
+// out the diff if there is none.
+func artifacts(ext, got, want, diff string) map[string]string {
+	out := map[string]string{
+		"got" + ext:  got,
+		"want" + ext: want,
+	}
+	if diff != "" {
+		out["diff.txt"] = color.Strip(diff)
+	}
+	return out
+}
@@ -0,0 +1,267 @@
+package be
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/ghostlib"
+	"github.com/rliebz/ghost/internal/limits"
+)
+
+// Each asserts that an assertion is Ok for every element of a slice.
+func Each[T any](slice []T, f func(element T) ghost.Result) ghost.Result {
+	args := ghostlib.ArgsFromAST(slice, f)
+	return each("be.Each", args, sliceResults(slice, f))
+}
+
+// EachEntry asserts that an assertion is Ok for every entry of a map.
+func EachEntry[K comparable, V any](m map[K]V, f func(key K, value V) ghost.Result) ghost.Result {
+	args := ghostlib.ArgsFromAST(m, f)
+	return each("be.EachEntry", args, mapResults(m, f))
+}
+
+func each(name string, args []string, er elementResults) ghost.Result {
+	argCollection := args[0]
+
+	details := &ghost.Details{Name: name, Args: args, Children: er.results}
+
+	failed := er.matching(false)
+	if len(failed) == 0 {
+		return ghost.Result{
+			Ok:      true,
+			Message: fmt.Sprintf("all %d %s of %s are ok", len(er.results), er.noun, argCollection),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(
+			"%d of %d %s of %s are not ok: %s%s",
+			len(failed), len(er.results), er.noun, argCollection,
+			er.labelsOf(failed),
+			er.describe(failed),
+		),
+		Details: details,
+	}
+}
+
+// AnySuch asserts that an assertion is Ok for at least one element of a
+// slice.
+func AnySuch[T any](slice []T, f func(element T) ghost.Result) ghost.Result {
+	args := ghostlib.ArgsFromAST(slice, f)
+	argSlice := args[0]
+
+	er := sliceResults(slice, f)
+	details := &ghost.Details{Name: "be.AnySuch", Args: args, Children: er.results}
+
+	passed := er.matching(true)
+	if len(passed) == 0 {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(
+				"none of %d elements of %s are ok%s",
+				len(er.results), argSlice,
+				er.describe(er.matching(false)),
+			),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: true,
+		Message: fmt.Sprintf(
+			"%d of %d elements of %s are ok: %s%s",
+			len(passed), len(er.results), argSlice,
+			er.labelsOf(passed),
+			er.describe(passed),
+		),
+		Details: details,
+	}
+}
+
+// NoneSuch asserts that an assertion is not Ok for any element of a slice.
+func NoneSuch[T any](slice []T, f func(element T) ghost.Result) ghost.Result {
+	args := ghostlib.ArgsFromAST(slice, f)
+	argSlice := args[0]
+
+	er := sliceResults(slice, f)
+	details := &ghost.Details{Name: "be.NoneSuch", Args: args, Children: er.results}
+
+	passed := er.matching(true)
+	if len(passed) == 0 {
+		return ghost.Result{
+			Ok:      true,
+			Message: fmt.Sprintf("none of %d elements of %s are ok", len(er.results), argSlice),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(
+			"%d of %d elements of %s are ok: %s%s",
+			len(passed), len(er.results), argSlice,
+			er.labelsOf(passed),
+			er.describe(passed),
+		),
+		Details: details,
+	}
+}
+
+// ExactlyN asserts that an assertion is Ok for exactly n elements of a slice.
+func ExactlyN[T any](n int, slice []T, f func(element T) ghost.Result) ghost.Result {
+	args := ghostlib.ArgsFromAST(n, slice, f)
+	argSlice := args[1]
+
+	er := sliceResults(slice, f)
+	passed := er.matching(true)
+
+	details := &ghost.Details{
+		Name:     "be.ExactlyN",
+		Args:     args,
+		Got:      len(passed),
+		Want:     n,
+		Children: er.results,
+	}
+
+	switch {
+	case len(passed) == n:
+		var labels string
+		if len(passed) > 0 {
+			labels = ": " + er.labelsOf(passed)
+		}
+
+		return ghost.Result{
+			Ok: true,
+			Message: fmt.Sprintf(
+				"%d of %d elements of %s are ok%s",
+				len(passed), len(er.results), argSlice, labels,
+			),
+			Details: details,
+		}
+	case len(passed) > n:
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(
+				"%d of %d elements of %s are ok, not %d: %s%s",
+				len(passed), len(er.results), argSlice, n,
+				er.labelsOf(passed),
+				er.describe(passed),
+			),
+			Details: details,
+		}
+	default:
+		failed := er.matching(false)
+
+		var labels string
+		if len(failed) > 0 {
+			labels = "; not ok: " + er.labelsOf(failed)
+		}
+
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(
+				"%d of %d elements of %s are ok, not %d%s%s",
+				len(passed), len(er.results), argSlice, n, labels,
+				er.describe(failed),
+			),
+			Details: details,
+		}
+	}
+}
+
+// elementResults are the results of applying an assertion to each element of
+// a collection.
+type elementResults struct {
+	// noun describes the elements of the collection.
+	noun string
+	// singular describes one element of the collection.
+	singular string
+	// kind describes what identifies each element, such as an index or key.
+	kind    string
+	labels  []string
+	results []ghost.Result
+}
+
+func sliceResults[T any](slice []T, f func(T) ghost.Result) elementResults {
+	er := elementResults{
+		noun:     "elements",
+		singular: "element",
+		kind:     "element",
+		labels:   make([]string, 0, len(slice)),
+		results:  make([]ghost.Result, 0, len(slice)),
+	}
+
+	for i, e := range slice {
+		er.labels = append(er.labels, strconv.Itoa(i))
+		er.results = append(er.results, f(e))
+	}
+
+	return er
+}
+
+func mapResults[K comparable, V any](m map[K]V, f func(K, V) ghost.Result) elementResults {
+	er := elementResults{
+		noun:     "entries",
+		singular: "entry",
+		kind:     "key",
+		labels:   make([]string, 0, len(m)),
+		results:  make([]ghost.Result, 0, len(m)),
+	}
+
+	for _, k := range sortedKeys(m) {
+		er.labels = append(er.labels, fmt.Sprint(k))
+		er.results = append(er.results, f(k, m[k]))
+	}
+
+	return er
+}
+
+// matching returns the positions of every result that is or is not Ok.
+func (er elementResults) matching(ok bool) []int {
+	var out []int
+	for i, result := range er.results {
+		if result.Ok == ok {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// labelsOf lists the indexes or keys at the given positions.
+func (er elementResults) labelsOf(positions []int) string {
+	limit := limits.Elements()
+
+	labels := make([]string, 0, len(positions))
+	for n, i := range positions {
+		if limits.Exceeded(n+1, limit) {
+			labels = append(labels, limits.Omitted(len(positions)-n, er.singular, er.noun))
+			break
+		}
+		labels = append(labels, er.labels[i])
+	}
+	return strings.Join(labels, ", ")
+}
+
+// describe nests the messages of the results at the given positions.
+func (er elementResults) describe(positions []int) string {
+	limit := limits.Elements()
+
+	var b strings.Builder
+	for n, i := range positions {
+		if limits.Exceeded(n+1, limit) {
+			b.WriteString("\n\n")
+			b.WriteString(limits.Omitted(len(positions)-n, er.singular, er.noun))
+			break
+		}
+
+		result := er.results[i]
+		fmt.Fprintf(&b, "\n\n%s %s is %t", er.kind, er.labels[i], result.Ok)
+		b.WriteString("\n\t")
+		b.WriteString(indentString(result.Message))
+	}
+	return b.String()
+}
@@ -0,0 +1,214 @@
+package be_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+func isEven(n int) ghost.Result {
+	return be.Equal(n%2, 0)
+}
+
+func TestEach(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{2, 4, 6}
+
+		result := be.Each(nums, isEven)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "all 3 elements of nums are ok"))
+		g.Should(be.Equal(len(result.Details.Children), 3))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.Each([]int{}, isEven)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "all 0 elements of []int{} are ok"))
+	})
+
+	t.Run("not ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{1, 2, 3}
+
+		result := be.Each(nums, isEven)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `2 of 3 elements of nums are not ok: 0, 2
+
+element 0 is false
+	n % 2 != 0
+	got:  1
+	want: 0
+
+element 2 is false
+	n % 2 != 0
+	got:  1
+	want: 0`))
+	})
+}
+
+func TestEachEntry(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		m := map[string]int{"a": 2, "b": 4}
+
+		result := be.EachEntry(m, func(_ string, v int) ghost.Result {
+			return isEven(v)
+		})
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "all 2 entries of m are ok"))
+	})
+
+	t.Run("not ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		m := map[string]int{"a": 2, "b": 3, "c": 5}
+
+		result := be.EachEntry(m, func(_ string, v int) ghost.Result {
+			return isEven(v)
+		})
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `2 of 3 entries of m are not ok: b, c
+
+key b is false
+	n % 2 != 0
+	got:  1
+	want: 0
+
+key c is false
+	n % 2 != 0
+	got:  1
+	want: 0`))
+	})
+}
+
+func TestAnySuch(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{1, 2, 3}
+
+		result := be.AnySuch(nums, isEven)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `1 of 3 elements of nums are ok: 1
+
+element 1 is true
+	n % 2 == 0`))
+	})
+
+	t.Run("not ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{1, 3}
+
+		result := be.AnySuch(nums, isEven)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `none of 2 elements of nums are ok
+
+element 0 is false
+	n % 2 != 0
+	got:  1
+	want: 0
+
+element 1 is false
+	n % 2 != 0
+	got:  1
+	want: 0`))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.AnySuch(nil, isEven)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, "none of 0 elements of nil are ok"))
+	})
+}
+
+func TestNoneSuch(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{1, 3}
+
+		result := be.NoneSuch(nums, isEven)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "none of 2 elements of nums are ok"))
+	})
+
+	t.Run("not ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{1, 2, 3, 4}
+
+		result := be.NoneSuch(nums, isEven)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `2 of 4 elements of nums are ok: 1, 3
+
+element 1 is true
+	n % 2 == 0
+
+element 3 is true
+	n % 2 == 0`))
+	})
+}
+
+func TestExactlyN(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{1, 2, 3, 4}
+
+		result := be.ExactlyN(2, nums, isEven)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "2 of 4 elements of nums are ok: 1, 3"))
+
+		result = be.ExactlyN(0, []int{1}, isEven)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "0 of 1 elements of []int{1} are ok"))
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{1, 2, 4}
+
+		result := be.ExactlyN(1, nums, isEven)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `2 of 3 elements of nums are ok, not 1: 1, 2
+
+element 1 is true
+	n % 2 == 0
+
+element 2 is true
+	n % 2 == 0`))
+	})
+
+	t.Run("too few", func(t *testing.T) {
+		g := ghost.New(t)
+
+		nums := []int{1, 2, 3}
+
+		result := be.ExactlyN(2, nums, isEven)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `1 of 3 elements of nums are ok, not 2; not ok: 0, 2
+
+element 0 is false
+	n % 2 != 0
+	got:  1
+	want: 0
+
+element 2 is false
+	n % 2 != 0
+	got:  1
+	want: 0`))
+		g.Should(be.DeepEqual(result.Details.Got, any(1)))
+		g.Should(be.DeepEqual(result.Details.Want, any(2)))
+	})
+}
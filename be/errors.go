@@ -14,16 +14,20 @@ func Error(err error) ghost.Result {
 	args := ghostlib.ArgsFromAST(err)
 	argErr := args[0]
 
+	details := &ghost.Details{Name: "be.Error", Args: args, Got: err}
+
 	if err == nil {
 		return ghost.Result{
 			Ok:      false,
 			Message: argErr + " is nil",
+			Details: details,
 		}
 	}
 
 	return ghost.Result{
 		Ok:      true,
 		Message: fmt.Sprintf("%s has error value: %s", argErr, err),
+		Details: details,
 	}
 }
 
@@ -32,6 +36,8 @@ func ErrorContaining(err error, msg string) ghost.Result {
 	args := ghostlib.ArgsFromAST(err, msg)
 	argErr, argMsg := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.ErrorContaining", Args: args, Got: err, Want: msg}
+
 	switch {
 	case err == nil && argMsg == fmt.Sprintf("%q", msg):
 		return ghost.Result{
@@ -42,6 +48,7 @@ want: %v`,
 				argErr,
 				msg,
 			),
+			Details: details,
 		}
 	case err == nil:
 		return ghost.Result{
@@ -53,6 +60,7 @@ want: %v`,
 				argMsg,
 				msg,
 			),
+			Details: details,
 		}
 	case strings.Contains(err.Error(), msg):
 		return ghost.Result{
@@ -65,6 +73,7 @@ want: %v`,
 				err,
 				msg,
 			),
+			Details: details,
 		}
 	default:
 		return ghost.Result{
@@ -77,6 +86,7 @@ want: %v`,
 				err,
 				msg,
 			),
+			Details: details,
 		}
 	}
 }
@@ -86,6 +96,8 @@ func ErrorEqual(err error, msg string) ghost.Result {
 	args := ghostlib.ArgsFromAST(err, msg)
 	argErr, argMsg := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.ErrorEqual", Args: args, Got: err, Want: msg}
+
 	if err == nil {
 		return ghost.Result{
 			Ok: false,
@@ -95,6 +107,7 @@ want: %v`,
 				argErr,
 				msg,
 			),
+			Details: details,
 		}
 	}
 
@@ -107,6 +120,7 @@ value: %v`,
 				argMsg,
 				err,
 			),
+			Details: details,
 		}
 	}
 
@@ -120,6 +134,7 @@ want: %v`,
 			err,
 			msg,
 		),
+		Details: details,
 	}
 }
 
@@ -128,6 +143,8 @@ func ErrorIs(err error, target error) ghost.Result {
 	args := ghostlib.ArgsFromAST(err, target)
 	argErr, argTarget := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.ErrorIs", Args: args, Got: err, Want: target}
+
 	if errors.Is(err, target) {
 		return ghost.Result{
 			Ok: true,
@@ -139,6 +156,7 @@ target: %v`,
 				err,
 				target,
 			),
+			Details: details,
 		}
 	}
 
@@ -152,6 +170,7 @@ target: %v`,
 			err,
 			target,
 		),
+		Details: details,
 	}
 }
 
@@ -160,10 +179,13 @@ func ErrorAs[T any](err error, target *T) ghost.Result {
 	args := ghostlib.ArgsFromAST(err, target)
 	argErr, argTarget := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.ErrorAs", Args: args, Got: err, Want: target}
+
 	if err == nil {
 		return ghost.Result{
 			Ok:      false,
 			Message: fmt.Sprintf("error %v was nil", argErr),
+			Details: details,
 		}
 	}
 
@@ -171,6 +193,7 @@ func ErrorAs[T any](err error, target *T) ghost.Result {
 		return ghost.Result{
 			Ok:      false,
 			Message: fmt.Sprintf("target %v cannot be nil", argTarget),
+			Details: details,
 		}
 	}
 
@@ -185,6 +208,7 @@ target: %T`,
 				err,
 				*target,
 			),
+			Details: details,
 		}
 	}
 
@@ -198,5 +222,6 @@ target: %T`,
 			err,
 			*target,
 		),
+		Details: details,
 	}
 }
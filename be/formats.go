@@ -0,0 +1,32 @@
+package be
+
+import (
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/ghostlib"
+	"github.com/rliebz/ghost/internal/decode"
+	"github.com/rliebz/ghost/internal/jsondiff"
+)
+
+var (
+	yamlFormat = format{name: "YAML", decode: decode.YAML}
+	xmlFormat  = format{name: "XML", decode: decode.XML}
+)
+
+// YAMLEqual asserts that two sets of YAML-encoded data are equivalent,
+// regardless of formatting or the order of mapping keys.
+//
+// Inputs containing more than one document are not valid.
+func YAMLEqual[T ~string | ~[]byte](got, want T) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, want)
+	return decodedEqual(got, want, yamlFormat, "be.YAMLEqual", args, jsondiff.Options{})
+}
+
+// XMLEqual asserts that two sets of XML-encoded data are equivalent,
+// regardless of formatting, the order of attributes, or whitespace around
+// text.
+//
+// The order of child elements, and of the text between them, is significant.
+func XMLEqual[T ~string | ~[]byte](got, want T) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, want)
+	return decodedEqual(got, want, xmlFormat, "be.XMLEqual", args, jsondiff.Options{})
+}
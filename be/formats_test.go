@@ -0,0 +1,153 @@
+package be_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+func TestYAMLEqual(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := "name: app\nports: [80, 443]\n"
+		want := `
+ports:
+  - 80
+  - 443
+name: "app"
+`
+
+		result := be.YAMLEqual(got, want)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "got and want are YAML equal"))
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := "name: app\nreplicas: 2\n"
+		want := "name: app\nreplicas: 3\n"
+
+		result := be.YAMLEqual(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got and want are not YAML equal
+diff (-want +got):
+~ /replicas: 3 => 2
+
+  {
+    "name": "app",
+~   "replicas": 3 => 2
+  }`))
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		g := ghost.New(t)
+
+		valid := "a: 1"
+		invalid := "a: ["
+		invalid2 := "- ]"
+
+		result := be.YAMLEqual(valid, invalid)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid is not valid YAML
+value: a: [`))
+
+		result = be.YAMLEqual(invalid, valid)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid is not valid YAML
+value: a: [`))
+
+		multiple := "a: 1\n---\nb: 2"
+
+		result = be.YAMLEqual(valid, multiple)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `multiple is not valid YAML
+value: a: 1
+---
+b: 2`))
+
+		result = be.YAMLEqual(invalid, invalid2)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid and invalid2 are not valid YAML
+got:
+a: [
+
+want:
+- ]`))
+	})
+}
+
+func TestXMLEqual(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `<server port="80" host="example.com"><name>web</name></server>`
+		want := `
+<server host="example.com" port="80">
+  <name> web </name>
+</server>`
+
+		result := be.XMLEqual(got, want)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "got and want are XML equal"))
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `<server port="8080"><name>web</name><tag>a</tag></server>`
+		want := `<server port="80"><name>web</name><tag>a</tag><tag>b</tag></server>`
+
+		result := be.XMLEqual(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got and want are not XML equal
+diff (-want +got):
+- /server/#children/2: {...}
+~ /server/@port: "80" => "8080"
+
+  {
+    "server": {
+      "#children": [
+        {
+          "name": "web"
+        },
+        {
+          "tag": "a"
+        },
+-       {
+-         "tag": "b"
+-       }
+      ],
+~     "@port": "80" => "8080"
+    }
+  }`))
+	})
+
+	t.Run("element order", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.XMLEqual(`<r><a/><b/></r>`, `<r><b/><a/></r>`)
+		g.Should(be.False(result.Ok))
+	})
+
+	t.Run("text order", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.XMLEqual(`<p>a<b/>c</p>`, `<p>ac<b/></p>`)
+		g.Should(be.False(result.Ok))
+	})
+
+	t.Run("invalid xml", func(t *testing.T) {
+		g := ghost.New(t)
+
+		valid := `<a/>`
+		invalid := `<a>`
+
+		result := be.XMLEqual(valid, invalid)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid is not valid XML
+value: <a>`))
+	})
+}
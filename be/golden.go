@@ -0,0 +1,125 @@
+package be
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/ghostlib"
+	"github.com/rliebz/ghost/internal/update"
+)
+
+// GoldenEqual asserts that a value is equal to the contents of a golden file.
+//
+// When tests are run with the -ghost.update flag or with GHOST_UPDATE=1, the
+// golden file is written with the value instead, creating it if necessary.
+func GoldenEqual[T ~string | ~[]byte](got T, path string) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, path)
+	argGot, argPath := args[0], args[1]
+
+	if update.Enabled() {
+		return updateGolden(string(got), path, args)
+	}
+
+	want, err := os.ReadFile(path) //nolint:gosec // user-provided path
+	if errors.Is(err, fs.ErrNotExist) {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`golden file %v does not exist
+path: %s
+
+To create it, run tests with -ghost.update or GHOST_UPDATE=1`,
+				argPath,
+				path,
+			),
+			Details: &ghost.Details{Name: "be.GoldenEqual", Args: args, Got: string(got)},
+		}
+	}
+	if err != nil {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`golden file %v could not be read
+%v`,
+				argPath,
+				err,
+			),
+			Details: &ghost.Details{Name: "be.GoldenEqual", Args: args, Got: string(got)},
+		}
+	}
+
+	details := &ghost.Details{
+		Name: "be.GoldenEqual",
+		Args: args,
+		Got:  string(got),
+		Want: string(want),
+	}
+
+	if string(got) == string(want) {
+		return ghost.Result{
+			Ok:      true,
+			Message: fmt.Sprintf("%v matches golden file %v", argGot, argPath),
+			Details: details,
+		}
+	}
+
+	details.Artifacts = stringArtifacts(string(got), string(want))
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(`%v does not match golden file %v
+%v`,
+			argGot,
+			argPath,
+			stringDiff(string(got), string(want)),
+		),
+		Details: details,
+	}
+}
+
+// updateGolden writes a value to a golden file.
+func updateGolden(got string, path string, args []string) ghost.Result {
+	argGot, argPath := args[0], args[1]
+
+	details := &ghost.Details{Name: "be.GoldenEqual", Args: args, Got: got, Want: got}
+
+	if want, err := os.ReadFile(path); err == nil && string(want) == got { //nolint:gosec
+		return ghost.Result{
+			Ok:      true,
+			Message: fmt.Sprintf("%v matches golden file %v", argGot, argPath),
+			Details: details,
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`golden file %v could not be updated
+%v`,
+				argPath,
+				err,
+			),
+			Details: details,
+		}
+	}
+
+	//nolint:gosec // golden files are checked in, so should be readable
+	if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`golden file %v could not be updated
+%v`,
+				argPath,
+				err,
+			),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok:      true,
+		Message: fmt.Sprintf("golden file %v was updated with %v", argPath, argGot),
+		Details: details,
+	}
+}
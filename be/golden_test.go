@@ -0,0 +1,112 @@
+package be_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+func TestGoldenEqual(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		path := filepath.Join(t.TempDir(), "equal.golden")
+		g.NoError(os.WriteFile(path, []byte("foo\nbar\n"), 0o600))
+
+		got := "foo\nbar\n"
+
+		result := be.GoldenEqual(got, path)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "got matches golden file path"))
+
+		result = be.GoldenEqual([]byte("foo\nbar\n"), path)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(
+			result.Message,
+			`[]byte("foo\nbar\n") matches golden file path`,
+		))
+	})
+
+	t.Run("not equal single line", func(t *testing.T) {
+		g := ghost.New(t)
+
+		path := filepath.Join(t.TempDir(), "single.golden")
+		g.NoError(os.WriteFile(path, []byte("foo"), 0o600))
+
+		got := "bar"
+
+		result := be.GoldenEqual(got, path)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got does not match golden file path
+got:  "bar"
+want: "foo"
+`))
+	})
+
+	t.Run("not equal multiline", func(t *testing.T) {
+		g := ghost.New(t)
+
+		path := filepath.Join(t.TempDir(), "multi.golden")
+		g.NoError(os.WriteFile(path, []byte("foo\nbar\nbaz"), 0o600))
+
+		got := "bar"
+
+		result := be.GoldenEqual(got, path)
+		g.Should(be.False(result.Ok))
+
+		g.Should(be.Equal(result.Message, `got does not match golden file path
+diff (-want +got):
+@@ -1,3 +1 @@
+-foo
+-bar
+-baz
+\ No newline at end of file
++bar
+\ No newline at end of file
+`))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		g := ghost.New(t)
+
+		path := filepath.Join(t.TempDir(), "missing.golden")
+
+		result := be.GoldenEqual("foo", path)
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(result.Message, "golden file path does not exist"))
+		g.Should(be.StringContaining(result.Message, "-ghost.update or GHOST_UPDATE=1"))
+
+		_, err := os.Stat(path)
+		g.Should(be.ErrorIs(err, os.ErrNotExist))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_UPDATE", "1")
+
+		path := filepath.Join(t.TempDir(), "testdata", "update.golden")
+
+		result := be.GoldenEqual("foo", path)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `golden file path was updated with "foo"`))
+
+		data, err := os.ReadFile(path)
+		g.NoError(err)
+		g.Should(be.Equal(string(data), "foo"))
+
+		result = be.GoldenEqual("foo", path)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `"foo" matches golden file path`))
+
+		result = be.GoldenEqual([]byte("bar"), path)
+		g.Should(be.True(result.Ok))
+
+		data, err = os.ReadFile(path)
+		g.NoError(err)
+		g.Should(be.Equal(string(data), "bar"))
+	})
+}
@@ -0,0 +1,199 @@
+package be
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/ghostlib"
+	"github.com/rliebz/ghost/internal/color"
+	"github.com/rliebz/ghost/internal/jsondiff"
+	"github.com/rliebz/ghost/internal/jsonschema"
+)
+
+// A JSONOption customizes how JSON-encoded data is compared.
+type JSONOption func(*jsondiff.Options)
+
+// JSONArraySubset allows arrays to contain elements that were not wanted, as
+// long as every wanted element appears in the same order.
+//
+// To allow wanted elements to appear in any order, combine it with
+// [JSONUnorderedArrays].
+func JSONArraySubset() JSONOption {
+	return func(o *jsondiff.Options) {
+		o.SubsetArrays = true
+	}
+}
+
+// JSONCollapse replaces runs of unchanged values in the diff with a count,
+// which keeps failures for large documents readable.
+func JSONCollapse() JSONOption {
+	return func(o *jsondiff.Options) {
+		o.Collapse = true
+	}
+}
+
+// JSONIgnorePaths skips comparing the values at the given JSON Pointer paths,
+// such as "/meta/requestId" or "/items/0/createdAt".
+func JSONIgnorePaths(paths ...string) JSONOption {
+	return func(o *jsondiff.Options) {
+		o.IgnorePaths = append(o.IgnorePaths, paths...)
+	}
+}
+
+// JSONNullAsMissing treats object keys with a null value as equal to keys
+// that are not present.
+func JSONNullAsMissing() JSONOption {
+	return func(o *jsondiff.Options) {
+		o.NullAsMissing = true
+	}
+}
+
+// JSONNumeric compares numbers by their value rather than their encoding, so
+// that 1.0, 1 and 1e0 are all equal.
+func JSONNumeric() JSONOption {
+	return func(o *jsondiff.Options) {
+		o.NumericNumbers = true
+	}
+}
+
+// JSONNumberTolerance compares numbers by their value, allowing them to
+// differ by up to delta.
+func JSONNumberTolerance(delta float64) JSONOption {
+	return func(o *jsondiff.Options) {
+		o.NumberTolerance = delta
+	}
+}
+
+// JSONUnorderedArrays matches the elements of arrays regardless of their
+// order, treating them as sets. Duplicate elements must appear the same number
+// of times.
+//
+// If any JSON Pointer paths are given, only the arrays at those paths are
+// unordered. Otherwise, every array is.
+func JSONUnorderedArrays(paths ...string) JSONOption {
+	return func(o *jsondiff.Options) {
+		if len(paths) == 0 {
+			o.UnorderedArrays = true
+			return
+		}
+		o.UnorderedPaths = append(o.UnorderedPaths, paths...)
+	}
+}
+
+func jsonOptions(opts []JSONOption) jsondiff.Options {
+	var o jsondiff.Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// JSONEqualWith asserts that two sets of JSON-encoded data are equivalent,
+// using options to customize the comparison.
+func JSONEqualWith[T ~string | ~[]byte](got, want T, opts ...JSONOption) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, want, opts)
+	return decodedEqual(got, want, jsonFormat, "be.JSONEqualWith", args, jsonOptions(opts))
+}
+
+// JSONContaining asserts that JSON-encoded data contains a subset of other
+// JSON-encoded data.
+//
+// Every key of each object in want must exist in got with a matching value,
+// but objects in got may have other keys. Arrays must match element by
+// element, unless options such as [JSONArraySubset] are provided.
+func JSONContaining[T ~string | ~[]byte](got, want T, opts ...JSONOption) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, want, opts)
+	argGot, argWant := args[0], args[1]
+
+	details := &ghost.Details{Name: "be.JSONContaining", Args: args, Got: got, Want: want}
+
+	o := jsonOptions(opts)
+	o.Subset = true
+	o.Summary = true
+
+	diff, kind := colorDecodedDiff(got, want, jsonFormat, o)
+
+	if kind == jsondiff.Match {
+		return ghost.Result{
+			Ok:      true,
+			Message: fmt.Sprintf("%v contains JSON %v", argGot, argWant),
+			Details: details,
+		}
+	}
+
+	if result, ok := invalidInput(jsonFormat, kind, got, want, args, details); ok {
+		return result
+	}
+
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(`%v does not contain JSON %v
+%s`, argGot, argWant, diff),
+		Details: details,
+	}
+}
+
+// JSONMatchingSchema asserts that JSON-encoded data is valid against a JSON
+// Schema.
+//
+// A subset of JSON Schema draft 2020-12 is supported: type, enum, const,
+// properties, required, additionalProperties, items, pattern, minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength, minItems,
+// maxItems, and $ref within the same schema. Other keywords are ignored.
+func JSONMatchingSchema[T ~string | ~[]byte](got, schema T) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, schema)
+	argGot, argSchema := args[0], args[1]
+
+	details := &ghost.Details{Name: "be.JSONMatchingSchema", Args: args, Got: got, Want: schema}
+
+	doc, err := jsondiff.Decode(got)
+	if err != nil {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%v is not valid JSON
+value: %s`, argGot, got),
+			Details: details,
+		}
+	}
+
+	s, err := jsondiff.Decode(schema)
+	if err != nil {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%v is not valid JSON
+value: %s`, argSchema, schema),
+			Details: details,
+		}
+	}
+
+	violations, err := jsonschema.Validate(doc, s)
+	if err != nil {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%v is not a valid JSON schema
+error: %v`, argSchema, err),
+			Details: details,
+		}
+	}
+
+	if len(violations) == 0 {
+		return ghost.Result{
+			Ok:      true,
+			Message: fmt.Sprintf("%v matches JSON schema %v", argGot, argSchema),
+			Details: details,
+		}
+	}
+
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, color.Red("\t"+v.String()))
+	}
+
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(`%v does not match JSON schema %v
+%s`, argGot, argSchema, strings.Join(lines, "\n")),
+		Details: details,
+	}
+}
@@ -0,0 +1,303 @@
+package be_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+func TestJSONEqualWith(t *testing.T) {
+	t.Run("no options", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `{"a": 1.0}`
+		want := `{"a": 1}`
+
+		result := be.JSONEqualWith(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got and want are not JSON equal
+diff (-want +got):
+~ /a: 1 => 1.0
+
+  {
+~   "a": 1 => 1.0
+  }`))
+	})
+
+	t.Run("ignore paths", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `{"meta": {"requestId": "a1"}, "items": [{"price": 10}]}`
+		want := `{"meta": {"requestId": "b2"}, "items": [{"price": 12}]}`
+
+		result := be.JSONEqualWith(got, want, be.JSONIgnorePaths("/meta/requestId"))
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got and want are not JSON equal
+diff (-want +got):
+~ /items/0/price: 12 => 10
+
+  {
+    "items": [
+      {
+~       "price": 12 => 10
+      }
+    ],
+    "meta": {
+      "requestId": <ignored>
+    }
+  }`))
+
+		result = be.JSONEqualWith(
+			got,
+			want,
+			be.JSONIgnorePaths("/meta/requestId", "/items/0/price"),
+		)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "got and want are JSON equal"))
+	})
+
+	t.Run("unordered arrays", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `{"tags": ["b", "a"], "ids": [2, 1]}`
+		want := `{"tags": ["a", "b"], "ids": [1, 2]}`
+
+		result := be.JSONEqualWith(got, want, be.JSONUnorderedArrays())
+		g.Should(be.True(result.Ok))
+
+		result = be.JSONEqualWith(got, want, be.JSONUnorderedArrays("/tags"))
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got and want are not JSON equal
+diff (-want +got):
+~ /ids/0: 1 => 2
+~ /ids/1: 2 => 1
+
+  {
+    "ids": [
+~     1 => 2,
+~     2 => 1
+    ],
+    "tags": [
+      "a",
+      "b"
+    ]
+  }`))
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.JSONEqualWith(`[1.0, 2e1]`, `[1, 20]`, be.JSONNumeric())
+		g.Should(be.True(result.Ok))
+
+		result = be.JSONEqualWith(`[1.001]`, `[1]`, be.JSONNumberTolerance(0.01))
+		g.Should(be.True(result.Ok))
+
+		result = be.JSONEqualWith(`[1.1]`, `[1]`, be.JSONNumberTolerance(0.01))
+		g.Should(be.False(result.Ok))
+	})
+
+	t.Run("null as missing", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `{"a": 1, "b": null}`
+		want := `{"a": 1}`
+
+		result := be.JSONEqualWith(got, want)
+		g.Should(be.False(result.Ok))
+
+		result = be.JSONEqualWith(got, want, be.JSONNullAsMissing())
+		g.Should(be.True(result.Ok))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		g := ghost.New(t)
+
+		valid := `{}`
+		invalid := `{{`
+
+		result := be.JSONEqualWith(valid, invalid, be.JSONNumeric())
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid is not valid JSON
+value: {{`))
+	})
+}
+
+func TestJSONCollapse(t *testing.T) {
+	g := ghost.New(t)
+
+	got := `{"id": 1, "name": "alice", "roles": ["admin", "dev", "ops"], "team": {"id": 2}}`
+	want := `{"id": 1, "name": "alice", "roles": ["admin", "qa", "ops"], "team": {"id": 2}}`
+
+	result := be.JSONEqualWith(got, want, be.JSONCollapse())
+	g.Should(be.False(result.Ok))
+	g.Should(be.Equal(result.Message, `got and want are not JSON equal
+diff (-want +got):
+~ /roles/1: "qa" => "dev"
+
+  {
+    ... (2 unchanged),
+    "roles": [
+      ... (1 unchanged),
+~     "qa" => "dev",
+      ... (1 unchanged)
+    ],
+    ... (1 unchanged)
+  }`))
+}
+
+func TestJSONContaining(t *testing.T) {
+	t.Run("contains", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `{"id": "4f2a", "name": "alice", "tags": ["a", "b"], "created": 1700000000}`
+		want := `{"name": "alice", "tags": ["a", "b"]}`
+
+		result := be.JSONContaining(got, want)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "got contains JSON want"))
+
+		result = be.JSONContaining(`{"a": 1, "b": 2}`, `{"a": 1}`)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "`{\"a\": 1, \"b\": 2}` contains JSON `{\"a\": 1}`"))
+	})
+
+	t.Run("does not contain", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `{"id": "4f2a", "name": "bob", "tags": ["a", "b", "c"]}`
+		want := `{"name": "alice", "tags": ["a", "b"], "email": "alice@example.com"}`
+
+		result := be.JSONContaining(got, want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got does not contain JSON want
+diff (-want +got):
+- /email: "alice@example.com"
+~ /name: "alice" => "bob"
++ /tags/2: "c"
+
+  {
+-   "email": "alice@example.com",
+~   "name": "alice" => "bob",
+    "tags": [
+      "a",
+      "b",
++     "c"
+    ]
+  }`))
+	})
+
+	t.Run("array subset", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `[{"id": 1, "ok": true}, {"id": 2, "ok": false}, {"id": 3, "ok": true}]`
+
+		result := be.JSONContaining(got, `[{"id": 1}, {"id": 3}]`, be.JSONArraySubset())
+		g.Should(be.True(result.Ok))
+
+		result = be.JSONContaining(got, `[{"id": 3}, {"id": 1}]`, be.JSONArraySubset())
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(result.Message, `diff (-want +got):
+- /1: {...}
+
+  [
+    {
+      "id": 3
+    },
+-   {
+-     "id": 1
+-   }
+  ]`))
+
+		result = be.JSONContaining(
+			got,
+			`[{"id": 3}, {"id": 1}]`,
+			be.JSONArraySubset(),
+			be.JSONUnorderedArrays(),
+		)
+		g.Should(be.True(result.Ok))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		g := ghost.New(t)
+
+		valid := `{"foo": "value"}`
+		invalid := `{{`
+
+		result := be.JSONContaining(valid, invalid)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid is not valid JSON
+value: {{`))
+
+		result = be.JSONContaining(invalid, valid)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid is not valid JSON
+value: {{`))
+	})
+}
+
+func TestJSONMatchingSchema(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["id"]
+	}`
+
+	t.Run("matches", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `{"id": 1, "tags": ["a"]}`
+
+		result := be.JSONMatchingSchema(got, schema)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "got matches JSON schema schema"))
+	})
+
+	t.Run("does not match", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := `{"id": 0, "tags": ["a", 2]}`
+
+		result := be.JSONMatchingSchema(got, schema)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got does not match JSON schema schema
+	/id: 0 is less than the minimum of 1
+	/tags/1: 2 is number, not string`))
+
+		result = be.JSONMatchingSchema(`[]`, schema)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, "`[]` does not match JSON schema schema\n"+
+			"\t(root): [] is array, not object"))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		g := ghost.New(t)
+
+		invalid := `{{`
+
+		result := be.JSONMatchingSchema(invalid, schema)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid is not valid JSON
+value: {{`))
+
+		result = be.JSONMatchingSchema(`{}`, invalid)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `invalid is not valid JSON
+value: {{`))
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		g := ghost.New(t)
+
+		schema := `{"pattern": "("}`
+
+		result := be.JSONMatchingSchema(`"foo"`, schema)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, "schema is not a valid JSON schema\n"+
+			"error: pattern \"(\": error parsing regexp: missing closing ): `(`"))
+	})
+}
@@ -13,6 +13,8 @@ func Greater[T constraints.Ordered](a, b T) ghost.Result {
 	args := ghostlib.ArgsFromAST(a, b)
 	argA, argB := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.Greater", Args: args, Got: a, Want: b}
+
 	if a > b {
 		return ghost.Result{
 			Ok: true,
@@ -20,6 +22,7 @@ func Greater[T constraints.Ordered](a, b T) ghost.Result {
 				inline(a, argA),
 				inline(b, argB),
 			),
+			Details: details,
 		}
 	}
 
@@ -29,6 +32,7 @@ func Greater[T constraints.Ordered](a, b T) ghost.Result {
 			inline(a, argA),
 			inline(b, argB),
 		),
+		Details: details,
 	}
 }
 
@@ -37,6 +41,8 @@ func GreaterOrEqual[T constraints.Ordered](a, b T) ghost.Result {
 	args := ghostlib.ArgsFromAST(a, b)
 	argA, argB := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.GreaterOrEqual", Args: args, Got: a, Want: b}
+
 	switch {
 	case a > b:
 		return ghost.Result{
@@ -45,6 +51,7 @@ func GreaterOrEqual[T constraints.Ordered](a, b T) ghost.Result {
 				inline(a, argA),
 				inline(b, argB),
 			),
+			Details: details,
 		}
 	case a == b:
 		return ghost.Result{
@@ -53,6 +60,7 @@ func GreaterOrEqual[T constraints.Ordered](a, b T) ghost.Result {
 				inline(a, argA),
 				inline(b, argB),
 			),
+			Details: details,
 		}
 	default:
 		return ghost.Result{
@@ -61,6 +69,7 @@ func GreaterOrEqual[T constraints.Ordered](a, b T) ghost.Result {
 				inline(a, argA),
 				inline(b, argB),
 			),
+			Details: details,
 		}
 	}
 }
@@ -70,6 +79,8 @@ func Less[T constraints.Ordered](a, b T) ghost.Result {
 	args := ghostlib.ArgsFromAST(a, b)
 	argA, argB := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.Less", Args: args, Got: a, Want: b}
+
 	if a < b {
 		return ghost.Result{
 			Ok: true,
@@ -77,6 +88,7 @@ func Less[T constraints.Ordered](a, b T) ghost.Result {
 				inline(a, argA),
 				inline(b, argB),
 			),
+			Details: details,
 		}
 	}
 
@@ -86,6 +98,7 @@ func Less[T constraints.Ordered](a, b T) ghost.Result {
 			inline(a, argA),
 			inline(b, argB),
 		),
+		Details: details,
 	}
 }
 
@@ -94,6 +107,8 @@ func LessOrEqual[T constraints.Ordered](a, b T) ghost.Result {
 	args := ghostlib.ArgsFromAST(a, b)
 	argA, argB := args[0], args[1]
 
+	details := &ghost.Details{Name: "be.LessOrEqual", Args: args, Got: a, Want: b}
+
 	switch {
 	case a < b:
 		return ghost.Result{
@@ -102,6 +117,7 @@ func LessOrEqual[T constraints.Ordered](a, b T) ghost.Result {
 				inline(a, argA),
 				inline(b, argB),
 			),
+			Details: details,
 		}
 	case a == b:
 		return ghost.Result{
@@ -110,6 +126,7 @@ func LessOrEqual[T constraints.Ordered](a, b T) ghost.Result {
 				inline(a, argA),
 				inline(b, argB),
 			),
+			Details: details,
 		}
 	default:
 		return ghost.Result{
@@ -118,6 +135,7 @@ func LessOrEqual[T constraints.Ordered](a, b T) ghost.Result {
 				inline(a, argA),
 				inline(b, argB),
 			),
+			Details: details,
 		}
 	}
 }
@@ -0,0 +1,206 @@
+package be
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/ghostlib"
+)
+
+// Panic asserts that a function panics.
+func Panic(f func()) ghost.Result {
+	args := ghostlib.ArgsFromAST(f)
+	argF := args[0]
+
+	p := catchPanic(f)
+
+	details := &ghost.Details{Name: "be.Panic", Args: args, Got: p.value}
+
+	if !p.panicked {
+		return ghost.Result{
+			Ok:      false,
+			Message: argF + " did not panic",
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: true,
+		Message: fmt.Sprintf(`%s panicked
+value: %v`,
+			argF,
+			p.value,
+		),
+		Details: details,
+	}
+}
+
+// PanicWith asserts that a function panics with a value deeply equal to
+// another.
+func PanicWith(f func(), want any) ghost.Result {
+	args := ghostlib.ArgsFromAST(f, want)
+	argF, argWant := args[0], args[1]
+
+	p := catchPanic(f)
+
+	details := &ghost.Details{Name: "be.PanicWith", Args: args, Got: p.value, Want: want}
+
+	if !p.panicked {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%s did not panic
+want: %v`,
+				argF,
+				want,
+			),
+			Details: details,
+		}
+	}
+
+	if diff := colorDiff(want, p.value); diff != "" {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%s did not panic with %s
+%v%s`,
+				argF,
+				argWant,
+				diff,
+				p.stack,
+			),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: true,
+		Message: fmt.Sprintf(`%s panicked with %s
+value: %v`,
+			argF,
+			argWant,
+			p.value,
+		),
+		Details: details,
+	}
+}
+
+// PanicMatching asserts that a function panics with a value for which match
+// returns an Ok [ghost.Result].
+func PanicMatching(f func(), match func(value any) ghost.Result) ghost.Result {
+	args := ghostlib.ArgsFromAST(f, match)
+	argF := args[0]
+
+	p := catchPanic(f)
+
+	details := &ghost.Details{Name: "be.PanicMatching", Args: args, Got: p.value}
+
+	if !p.panicked {
+		return ghost.Result{
+			Ok:      false,
+			Message: argF + " did not panic",
+			Details: details,
+		}
+	}
+
+	result := match(p.value)
+	details.Children = []ghost.Result{result}
+
+	// The stack is only useful for finding the cause of a failure.
+	verb, stack := "matching", ""
+	if !result.Ok {
+		verb, stack = "not matching", "\n"+p.stack
+	}
+
+	return ghost.Result{
+		Ok: result.Ok,
+		Message: fmt.Sprintf(`%s panicked with a value %s
+value: %v
+	%s%s`,
+			argF,
+			verb,
+			p.value,
+			indentString(result.Message),
+			stack,
+		),
+		Details: details,
+	}
+}
+
+// NotPanic asserts that a function does not panic.
+func NotPanic(f func()) ghost.Result {
+	args := ghostlib.ArgsFromAST(f)
+	argF := args[0]
+
+	p := catchPanic(f)
+
+	details := &ghost.Details{Name: "be.NotPanic", Args: args, Got: p.value}
+
+	if p.panicked {
+		return ghost.Result{
+			Ok: false,
+			Message: fmt.Sprintf(`%s panicked
+value: %v
+%s`,
+				argF,
+				p.value,
+				p.stack,
+			),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok:      true,
+		Message: argF + " did not panic",
+		Details: details,
+	}
+}
+
+type panicOutcome struct {
+	panicked bool
+	value    any
+	stack    string
+}
+
+// catchPanic runs a function, recovering the value and stack trace of any
+// panic that occurs.
+func catchPanic(f func()) (p panicOutcome) {
+	// Track completion separately from the recovered value, since a function
+	// may call panic(nil).
+	p.panicked = true
+	defer func() {
+		if !p.panicked {
+			return
+		}
+
+		p.value = recover()
+		p.stack = panicStack(debug.Stack())
+	}()
+
+	f()
+	p.panicked = false
+
+	return p
+}
+
+// panicStack formats the stack trace of a panicking goroutine, dropping the
+// frames used to recover from the panic.
+func panicStack(stack []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	if len(lines) == 0 {
+		return "stack:"
+	}
+
+	// Frames come in pairs of function and file lines following the goroutine
+	// header. Everything up to and including the call to panic is ours.
+	frames := lines[1:]
+	for i := 0; i+1 < len(frames); i += 2 {
+		if strings.HasPrefix(frames[i], "panic(") {
+			frames = frames[i+2:]
+			break
+		}
+	}
+
+	return "stack:\n\t" + indentString(lines[0]+"\n"+strings.Join(frames, "\n"))
+}
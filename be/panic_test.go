@@ -0,0 +1,181 @@
+package be_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+func TestPanic(t *testing.T) {
+	t.Run("panic", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() { panic("oh no") }
+
+		result := be.Panic(f)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `f panicked
+value: oh no`))
+	})
+
+	t.Run("nil panic", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.Panic(func() { panic(nil) }) //nolint:govet // test case
+		g.Should(be.True(result.Ok))
+		g.Should(be.True(strings.HasSuffix(result.Message, "} panicked\nvalue: <nil>")))
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() {}
+
+		result := be.Panic(f)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `f did not panic`))
+
+		result = be.Panic(func() {})
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `func() {
+} did not panic`))
+	})
+}
+
+func TestPanicWith(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() { panic("oh no") }
+		want := "oh no"
+
+		result := be.PanicWith(f, want)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `f panicked with want
+value: oh no`))
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() { panic("oh no") }
+
+		result := be.PanicWith(f, "oh yes")
+		g.Should(be.False(result.Ok))
+
+		result.Message = strings.ReplaceAll(result.Message, "\u00a0", " ")
+		g.Should(be.True(strings.HasPrefix(result.Message, `f did not panic with "oh yes"
+diff (-want +got):
+  string(
+- 	"oh yes",
++ 	"oh no",
+  )
+stack:
+	goroutine `)))
+	})
+
+	t.Run("different types", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() { panic(errors.New("oh no")) }
+
+		result := be.PanicWith(f, "oh no")
+		g.Should(be.False(result.Ok))
+		g.Should(be.StringContaining(result.Message, `f did not panic with "oh no"
+diff (-want +got):
+`))
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() {}
+
+		result := be.PanicWith(f, "oh no")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `f did not panic
+want: oh no`))
+	})
+}
+
+func TestPanicMatching(t *testing.T) {
+	t.Run("matching", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() { panic(errors.New("oh no")) }
+
+		result := be.PanicMatching(f, func(v any) ghost.Result {
+			var err error
+			return be.AssignedAs(v, &err)
+		})
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `f panicked with a value matching
+value: oh no
+	v (*errors.errorString) was assigned to &err (*error)
+	value: oh no`))
+		g.Should(be.Equal(len(result.Details.Children), 1))
+	})
+
+	t.Run("not matching", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() { panic("oh no") }
+
+		result := be.PanicMatching(f, func(v any) ghost.Result {
+			var err error
+			return be.AssignedAs(v, &err)
+		})
+		g.Should(be.False(result.Ok))
+		g.Should(be.True(strings.HasPrefix(result.Message, `f panicked with a value not matching
+value: oh no
+	v (string) could not be assigned to &err (*error)
+	value: oh no
+stack:
+	goroutine `)))
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() {}
+
+		result := be.PanicMatching(f, func(v any) ghost.Result {
+			return be.Nil(v)
+		})
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `f did not panic`))
+	})
+}
+
+func TestNotPanic(t *testing.T) {
+	t.Run("no panic", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() {}
+
+		result := be.NotPanic(f)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `f did not panic`))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		g := ghost.New(t)
+
+		f := func() {
+			var m map[string]int
+			m["foo"] = 1
+		}
+
+		result := be.NotPanic(f)
+		g.Should(be.False(result.Ok))
+		g.Should(be.True(strings.HasPrefix(result.Message, `f panicked
+value: assignment to entry in nil map
+stack:
+	goroutine `)))
+		g.Should(be.StringContaining(result.Message, "be_test.TestNotPanic"))
+		g.ShouldNot(be.StringContaining(result.Message, "be.catchPanic.func1"))
+	})
+}
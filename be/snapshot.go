@@ -0,0 +1,100 @@
+package be
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/ghostlib"
+	"github.com/rliebz/ghost/internal/update"
+)
+
+// Snapshot asserts that a value matches an inline snapshot.
+//
+// Strings and byte slices are compared as-is, and all other values are
+// compared using their Go-syntax representation.
+//
+// When tests are run with the -ghost.update flag or with GHOST_UPDATE=1, the
+// snapshot is rewritten in the caller's source code to match the value,
+// making it possible to start with an empty snapshot:
+//
+//	g.Should(be.Snapshot(got, ""))
+func Snapshot(got any, snapshot string) ghost.Result {
+	args := ghostlib.ArgsFromAST(got, snapshot)
+	argGot := args[0]
+
+	value := snapshotString(got)
+
+	details := &ghost.Details{Name: "be.Snapshot", Args: args, Got: value, Want: snapshot}
+
+	if value == snapshot {
+		return ghost.Result{
+			Ok:      true,
+			Message: fmt.Sprintf("%v matches snapshot", argGot),
+			Details: details,
+		}
+	}
+
+	if update.Enabled() {
+		if err := ghostlib.ReplaceArg(1, snapshotLiteral(value)); err != nil {
+			return ghost.Result{
+				Ok: false,
+				Message: fmt.Sprintf(`snapshot for %v could not be updated
+%v`,
+					argGot,
+					err,
+				),
+				Details: details,
+			}
+		}
+
+		return ghost.Result{
+			Ok:      true,
+			Message: fmt.Sprintf("snapshot for %v was updated", argGot),
+			Details: details,
+		}
+	}
+
+	return ghost.Result{
+		Ok: false,
+		Message: fmt.Sprintf(`%v does not match snapshot
+%v`,
+			argGot,
+			stringDiff(value, snapshot),
+		),
+		Details: details,
+	}
+}
+
+// snapshotString returns the representation of a value used in snapshots.
+func snapshotString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes())
+		}
+	}
+
+	return fmt.Sprintf("%#v", v)
+}
+
+// snapshotLiteral returns a Go string literal for a snapshot, preferring raw
+// strings for multiline values.
+func snapshotLiteral(s string) string {
+	if strings.Contains(s, "\n") && !strings.ContainsAny(s, "`\r") {
+		return "`" + s + "`"
+	}
+
+	return strconv.Quote(s)
+}
@@ -0,0 +1,70 @@
+package be_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("string equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := "foo\nbar"
+
+		result := be.Snapshot(got, `foo
+bar`)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "got matches snapshot"))
+	})
+
+	t.Run("struct equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		type T struct {
+			A string
+			B int
+		}
+
+		got := T{A: "foo", B: 1}
+
+		result := be.Snapshot(got, `be_test.T{A:"foo", B:1}`)
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, "got matches snapshot"))
+	})
+
+	t.Run("bytes equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		result := be.Snapshot([]byte("foo"), "foo")
+		g.Should(be.True(result.Ok))
+		g.Should(be.Equal(result.Message, `[]byte("foo") matches snapshot`))
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		g := ghost.New(t)
+
+		got := 15
+
+		result := be.Snapshot(got, "16")
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `got does not match snapshot
+got:  "15"
+want: "16"
+`))
+	})
+
+	t.Run("update variable", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_UPDATE", "1")
+
+		want := "old"
+
+		result := be.Snapshot("new", want)
+		g.Should(be.False(result.Ok))
+		g.Should(be.Equal(result.Message, `snapshot for "new" could not be updated
+argument is not a string literal: want`))
+	})
+}
@@ -66,6 +66,27 @@ func ExampleEventually() {
 	}, 100*time.Millisecond, 10*time.Millisecond))
 }
 
+func ExampleConsistently() {
+	t := new(testing.T) // from the test
+	g := ghost.New(t)
+
+	var count int
+	g.Should(be.Consistently(func() ghost.Result {
+		count++
+		return be.Greater(count, 0)
+	}, 100*time.Millisecond, 10*time.Millisecond))
+}
+
+func ExampleNever() {
+	t := new(testing.T) // from the test
+	g := ghost.New(t)
+
+	var closed bool
+	g.Should(be.Never(func() ghost.Result {
+		return be.True(closed)
+	}, 100*time.Millisecond, 10*time.Millisecond))
+}
+
 func ExampleAny() {
 	t := new(testing.T) // from the test
 	g := ghost.New(t)
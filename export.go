@@ -0,0 +1,341 @@
+package ghost
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/rliebz/ghost/internal/color"
+)
+
+// reportEnv is the environment variable used to enable writing a report of
+// every check to a file.
+const reportEnv = "GHOST_REPORT"
+
+// recording records the outcome of every check if a report is enabled.
+var recording = newRecorder(packageReportPath(os.Getenv(reportEnv)))
+
+// packageReportPath names the report for the package being tested, since each
+// package is tested by a separate binary that cannot share a file with the
+// others. For example, "report.xml" becomes "report.example.com_foo.xml" for
+// the package example.com/foo.
+func packageReportPath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	pkg := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	if info, ok := debug.ReadBuildInfo(); ok && info.Path != "" {
+		pkg = info.Path
+	}
+	pkg = strings.ReplaceAll(strings.TrimSuffix(pkg, ".test"), "/", "_")
+
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + pkg + ext
+}
+
+// RunWithReport runs the tests, recording the outcome of every check, and
+// writes a report to a file at path once they have finished. It is intended
+// to be called from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(ghost.RunWithReport(m, "ghost-report.xml"))
+//	}
+//
+// Paths ending in ".xml" are written as JUnit XML, and all other paths are
+// written as JSON lines. Every check becomes its own test case in JUnit
+// output, named after the test and the expression that was checked.
+//
+// Reports can also be enabled without TestMain by setting the GHOST_REPORT
+// environment variable to a path, in which case the report is written as each
+// test finishes. Relative paths are relative to the package being tested, and
+// the import path of the package is added before the extension so that each
+// package has its own report, such as "report.example.com_foo.xml".
+func RunWithReport(m interface{ Run() int }, path string) int {
+	prev := recording.swap(path)
+	defer recording.swap(prev)
+
+	code := m.Run()
+
+	if err := recording.flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "ghost: failed to write report: %s\n", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+
+	return code
+}
+
+// record adds the outcome of a check to the report, if enabled.
+//
+// It must be called directly by a check method, so the caller of that check
+// method can be used as the location of the check.
+func (g Ghost) record(check string, args []string, passed bool, result Result) {
+	expr := check
+	if len(args) > 0 {
+		expr = args[0]
+	}
+
+	name := testName(g.t)
+
+	recording.add(checkRecord{
+		Test:       name,
+		Check:      check,
+		Expression: expr,
+		Location:   callerLocation(2),
+		Passed:     passed,
+		Message:    color.Plain(result.Message),
+	})
+
+	if !recording.flushesEachTest() {
+		return
+	}
+
+	// Without a test name or cleanup to flush the report once the test has
+	// finished, flush after every check instead.
+	c, ok := g.t.(interface{ Cleanup(func()) })
+	if !ok || name == "" {
+		g.flushReport()
+		return
+	}
+
+	if recording.watch(name) {
+		c.Cleanup(g.flushReport)
+	}
+}
+
+func (g Ghost) flushReport() {
+	if err := recording.flush(); err != nil {
+		g.t.Log(fmt.Sprintf("ghost: failed to write report: %s", err))
+	}
+}
+
+// checkRecord is the outcome of a single check.
+type checkRecord struct {
+	Test       string
+	Check      string
+	Expression string
+	Location   Location
+	Passed     bool
+	Message    string
+}
+
+// recorder collects the outcome of checks and writes them to a report.
+type recorder struct {
+	m sync.Mutex
+
+	path    string
+	records []checkRecord
+	written int
+	watched map[string]struct{}
+	// eachTest is set if the report is written as each test finishes, rather
+	// than once by RunWithReport.
+	eachTest bool
+}
+
+func newRecorder(path string) *recorder {
+	return &recorder{
+		path:     path,
+		watched:  make(map[string]struct{}),
+		eachTest: path != "",
+	}
+}
+
+// enabled returns whether checks should be recorded.
+func (r *recorder) enabled() bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return r.path != ""
+}
+
+// flushesEachTest returns whether the report should be written as each test
+// finishes.
+func (r *recorder) flushesEachTest() bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return r.eachTest
+}
+
+// swap replaces the report path, returning the previous one. Any records
+// collected so far are discarded, and the report is written once, by the
+// caller, instead of as each test finishes.
+func (r *recorder) swap(path string) string {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	prev := r.path
+	r.path = path
+	r.records = nil
+	r.written = 0
+	r.watched = make(map[string]struct{})
+	r.eachTest = false
+	return prev
+}
+
+func (r *recorder) add(rec checkRecord) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.records = append(r.records, rec)
+}
+
+// watch returns true the first time it is called for a test name, so that the
+// report is only flushed once per test.
+func (r *recorder) watch(name string) bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if name == "" {
+		return false
+	}
+
+	if _, ok := r.watched[name]; ok {
+		return false
+	}
+
+	r.watched[name] = struct{}{}
+	return true
+}
+
+// flush writes every record collected so far to the report.
+func (r *recorder) flush() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.path == "" {
+		return nil
+	}
+
+	if strings.EqualFold(filepath.Ext(r.path), ".xml") {
+		return r.writeJUnit()
+	}
+
+	return r.writeJSONLines()
+}
+
+// writeJSONLines appends any records not yet written to the report. The
+// first write replaces any report left over from a previous run.
+func (r *recorder) writeJSONLines() error {
+	flags := os.O_WRONLY | os.O_APPEND | os.O_CREATE
+	if r.written == 0 {
+		flags = os.O_WRONLY | os.O_TRUNC | os.O_CREATE
+	}
+	f, err := os.OpenFile(r.path, flags, 0o600) //nolint:gosec // user-provided path
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	for _, rec := range r.records[r.written:] {
+		data, err := json.Marshal(jsonRecord{
+			Test:       rec.Test,
+			Check:      rec.Check,
+			Expression: rec.Expression,
+			Location:   fmt.Sprintf("%s:%d", rec.Location.File, rec.Location.Line),
+			Passed:     rec.Passed,
+			Message:    rec.Message,
+		})
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	if _, err := f.WriteString(buf.String()); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	r.written = len(r.records)
+	return f.Close()
+}
+
+// jsonRecord is the JSON lines representation of a [checkRecord].
+type jsonRecord struct {
+	Test       string `json:"test,omitempty"`
+	Check      string `json:"check"`
+	Expression string `json:"expression"`
+	Location   string `json:"location"`
+	Passed     bool   `json:"passed"`
+	Message    string `json:"message"`
+}
+
+// writeJUnit rewrites the report with every record collected so far.
+func (r *recorder) writeJUnit() error {
+	suite := junitSuite{
+		Name:  "ghost",
+		Tests: len(r.records),
+	}
+
+	for _, rec := range r.records {
+		tc := junitCase{
+			Name:      fmt.Sprintf("%s: %s", rec.Check, rec.Expression),
+			ClassName: rec.Test,
+			File:      rec.Location.File,
+			Line:      rec.Location.Line,
+		}
+
+		if !rec.Passed {
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message:  firstLine(rec.Message),
+				Type:     rec.Check,
+				Contents: fmt.Sprintf("%s\n%s", rec.Location, rec.Message),
+			}
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	data, err := xml.MarshalIndent(junitSuites{Suites: []junitSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	data = append([]byte(xml.Header), data...)
+	return os.WriteFile(r.path, append(data, '\n'), 0o600)
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i != -1 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,181 @@
+package ghost_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+)
+
+type mockM func()
+
+func (m mockM) Run() int {
+	m()
+	return 0
+}
+
+func TestRunWithReport(t *testing.T) {
+	t.Run("json lines", func(t *testing.T) {
+		g := ghost.New(t)
+
+		path := filepath.Join(t.TempDir(), "report.jsonl")
+
+		code := ghost.RunWithReport(mockM(func() {
+			testG := ghost.New(newMockT())
+
+			got := 1
+			testG.Should(be.Equal(got, 1))
+			testG.ShouldNot(be.Equal(got, 1))
+			testG.NoError(errors.New("oops"))
+		}), path)
+		g.Should(be.Equal(code, 0))
+
+		data, err := os.ReadFile(path)
+		g.NoError(err)
+
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		g.Must(be.SliceLen(lines, 3))
+
+		type record struct {
+			Check      string
+			Expression string
+			Location   string
+			Passed     bool
+			Message    string
+		}
+
+		var records []record
+		for _, line := range lines {
+			var r record
+			g.NoError(json.Unmarshal([]byte(line), &r))
+			records = append(records, r)
+		}
+
+		g.Should(be.Equal(records[0].Check, "Should"))
+		g.Should(be.Equal(records[0].Expression, "be.Equal(got, 1)"))
+		g.Should(be.StringMatching(records[0].Location, `export_test.go:\d+$`))
+		g.Should(be.True(records[0].Passed))
+		g.Should(be.Equal(records[0].Message, "got == 1"))
+
+		g.Should(be.Equal(records[1].Check, "ShouldNot"))
+		g.Should(be.Equal(records[1].Expression, "be.Equal(got, 1)"))
+		g.Should(be.False(records[1].Passed))
+
+		g.Should(be.Equal(records[2].Check, "NoError"))
+		g.Should(be.Equal(records[2].Expression, `errors.New("oops")`))
+		g.Should(be.False(records[2].Passed))
+		g.Should(be.Equal(records[2].Message, `errors.New("oops") has error value: oops`))
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		g := ghost.New(t)
+
+		path := filepath.Join(t.TempDir(), "report.xml")
+
+		code := ghost.RunWithReport(mockM(func() {
+			testG := ghost.New(newMockT())
+
+			testG.Should(be.Equal(1, 1))
+			testG.Should(be.Equal(1, 2))
+		}), path)
+		g.Should(be.Equal(code, 0))
+
+		data, err := os.ReadFile(path)
+		g.NoError(err)
+
+		report := string(data)
+		g.Should(be.StringContaining(report, `<testsuite name="ghost" tests="2" failures="1">`))
+		g.Should(be.StringContaining(report, `name="Should: be.Equal(1, 1)"`))
+		g.Should(be.StringContaining(report, `name="Should: be.Equal(1, 2)"`))
+		g.Should(be.StringContaining(report, `<failure message="1 != 2" type="Should">`))
+	})
+
+	t.Run("repeated", func(t *testing.T) {
+		g := ghost.New(t)
+
+		path := filepath.Join(t.TempDir(), "report.jsonl")
+
+		for i := 0; i < 2; i++ {
+			code := ghost.RunWithReport(mockM(func() {
+				ghost.New(newMockT()).Should(be.Equal(1, 1))
+			}), path)
+			g.Should(be.Equal(code, 0))
+		}
+
+		data, err := os.ReadFile(path)
+		g.NoError(err)
+		g.Should(be.SliceLen(strings.Split(strings.TrimSpace(string(data)), "\n"), 1))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		g := ghost.New(t)
+
+		path := filepath.Join(t.TempDir(), "report.jsonl")
+
+		code := ghost.RunWithReport(mockM(func() {}), path)
+		g.Should(be.Equal(code, 0))
+
+		ghost.New(newMockT()).Should(be.Equal(1, 2))
+
+		data, err := os.ReadFile(path)
+		g.NoError(err)
+		g.Should(be.Equal(string(data), ""))
+	})
+}
+
+func TestReportEnv(t *testing.T) {
+	// The report path is read when the package is initialized, so the checks
+	// run in a copy of the test binary with GHOST_REPORT set.
+	if os.Getenv("GHOST_TEST_REPORT_ENV") != "" {
+		t.Run("named", func(t *testing.T) {
+			ghost.New(t).Should(be.Equal(1, 1))
+		})
+
+		ghost.New(newMockT()).Should(be.Equal(1, 2))
+		return
+	}
+
+	g := ghost.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.jsonl")
+
+	// Run twice to ensure the report from the first run is replaced.
+	for i := 0; i < 2; i++ {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestReportEnv$") //nolint:gosec // test binary
+		cmd.Env = append(os.Environ(), "GHOST_TEST_REPORT_ENV=1", "GHOST_REPORT="+path)
+		out, err := cmd.CombinedOutput()
+		g.Should(be.Nil(err))
+		g.Should(be.StringContaining(string(out), "PASS"))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "report.github.com_rliebz_ghost.jsonl"))
+	g.NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	g.Must(be.SliceLen(lines, 2))
+
+	type record struct {
+		Test       string
+		Expression string
+		Passed     bool
+	}
+
+	var records []record
+	for _, line := range lines {
+		var r record
+		g.NoError(json.Unmarshal([]byte(line), &r))
+		records = append(records, r)
+	}
+
+	g.Should(be.DeepEqual(records, []record{
+		{Test: "TestReportEnv/named", Expression: "be.Equal(1, 1)", Passed: true},
+		{Expression: "be.Equal(1, 2)", Passed: false},
+	}))
+}
@@ -2,8 +2,13 @@ package ghost
 
 import (
 	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 
 	"github.com/rliebz/ghost/ghostlib"
+	"github.com/rliebz/ghost/internal/color"
 )
 
 // T is the subset of [*testing.T] used in assertions.
@@ -17,12 +22,50 @@ type T interface {
 
 // Ghost runs test assertions.
 type Ghost struct {
-	t T
+	t        T
+	reporter Reporter
+	batch    *batch
+	useColor *bool
 }
 
 // New creates a new [Ghost].
-func New(t T) Ghost {
-	return Ghost{t}
+func New(t T, opts ...Option) Ghost {
+	g := Ghost{
+		t:        t,
+		reporter: TextReporter{},
+	}
+
+	for _, opt := range opts {
+		opt(&g)
+	}
+
+	return g
+}
+
+// An Option configures a [Ghost].
+type Option func(g *Ghost)
+
+// WithReporter configures a [Ghost] to output failures using a [Reporter].
+//
+// By default, the message of each failure is logged using a [TextReporter].
+func WithReporter(r Reporter) Option {
+	return func(g *Ghost) {
+		g.reporter = r
+	}
+}
+
+// WithColor configures whether failures are described using color, whatever
+// the environment. Without color, differences within a line are marked using
+// a separate line instead.
+//
+// By default, colors are used if standard output is a terminal. This can be
+// changed with the FORCE_COLOR, CLICOLOR, CLICOLOR_FORCE, and TERM environment
+// variables. Setting NO_COLOR keeps assertions from using color at all, even
+// for a [Ghost] configured with WithColor(true).
+func WithColor(enabled bool) Option {
+	return func(g *Ghost) {
+		g.useColor = &enabled
+	}
 }
 
 // Should runs an assertion, returning true if the assertion was successful.
@@ -31,8 +74,14 @@ func (g Ghost) Should(result Result) bool {
 		h.Helper()
 	}
 
+	g.count()
+
+	if recording.enabled() {
+		g.record("Should", ghostlib.ArgsFromAST(result), result.Ok, result)
+	}
+
 	if !result.Ok {
-		g.t.Log(result.Message)
+		g.report(result)
 		g.t.Fail()
 		return false
 	}
@@ -47,8 +96,14 @@ func (g Ghost) ShouldNot(result Result) bool {
 		h.Helper()
 	}
 
+	g.count()
+
+	if recording.enabled() {
+		g.record("ShouldNot", ghostlib.ArgsFromAST(result), !result.Ok, result)
+	}
+
 	if result.Ok {
-		g.t.Log(result.Message)
+		g.report(result)
 		g.t.Fail()
 		return false
 	}
@@ -62,8 +117,16 @@ func (g Ghost) Must(result Result) {
 		h.Helper()
 	}
 
-	if !g.Should(result) {
-		g.t.FailNow()
+	g.count()
+
+	if recording.enabled() {
+		g.record("Must", ghostlib.ArgsFromAST(result), result.Ok, result)
+	}
+
+	if !result.Ok {
+		g.report(result)
+		g.t.Fail()
+		g.failNow()
 	}
 }
 
@@ -73,8 +136,16 @@ func (g Ghost) MustNot(result Result) {
 		h.Helper()
 	}
 
-	if !g.ShouldNot(result) {
-		g.t.FailNow()
+	g.count()
+
+	if recording.enabled() {
+		g.record("MustNot", ghostlib.ArgsFromAST(result), !result.Ok, result)
+	}
+
+	if result.Ok {
+		g.report(result)
+		g.t.Fail()
+		g.failNow()
 	}
 }
 
@@ -86,10 +157,230 @@ func (g Ghost) NoError(err error) {
 
 	args := ghostlib.ArgsFromAST(err)
 
+	g.count()
+
+	result := Result{
+		Ok:      err == nil,
+		Message: fmt.Sprintf("%s is nil", args[0]),
+	}
 	if err != nil {
-		g.t.Log(fmt.Sprintf("%s has error value: %s", args[0], err))
-		g.t.FailNow()
+		result.Message = fmt.Sprintf("%s has error value: %s", args[0], err)
+	}
+
+	if recording.enabled() {
+		g.record("NoError", args, result.Ok, result)
+	}
+
+	if !result.Ok {
+		g.report(result)
+		g.failNow()
+	}
+}
+
+// Collect runs a function with a [Ghost] that collects the results of every
+// check made within it. Once the function returns, any failures are reported
+// together as a single, numbered report, and Collect returns true if every
+// check was successful.
+//
+// Checks that end test execution, such as [Ghost.Must], report everything
+// collected up to that point before stopping the test.
+func (g Ghost) Collect(f func(g Ghost)) bool {
+	if h, ok := g.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	return g.collect(f, callerLocation(1))
+}
+
+// MustCollect is like [Ghost.Collect], but ends test execution if any of the
+// collected checks failed.
+func (g Ghost) MustCollect(f func(g Ghost)) {
+	if h, ok := g.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if !g.collect(f, callerLocation(1)) {
+		g.failNow()
+	}
+}
+
+func (g Ghost) collect(f func(g Ghost), location Location) bool {
+	if h, ok := g.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	g.count()
+
+	b := &batch{parent: g.batch, location: location}
+
+	inner := g
+	inner.batch = b
+	f(inner)
+
+	return g.flush(b)
+}
+
+// count records that a check was run.
+func (g Ghost) count() {
+	if g.batch != nil {
+		g.batch.count()
+	}
+}
+
+// report outputs a failed result using the reporter, or adds it to the
+// current batch if one exists.
+//
+// It must be called directly by a check method, so the caller of that check
+// method can be used as the location of the failure.
+func (g Ghost) report(result Result) {
+	if h, ok := g.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	result = withLocation(result, callerLocation(2))
+	result = withArtifacts(result, testName(g.t))
+	if !g.colorEnabled() {
+		result.Message = color.Plain(result.Message)
+	}
+
+	if g.batch == nil {
+		g.reporter.Report(g.t, result)
+		return
+	}
+
+	g.batch.add(result)
+}
+
+// colorEnabled returns whether failures are reported using color.
+func (g Ghost) colorEnabled() bool {
+	if g.useColor != nil {
+		return *g.useColor
+	}
+	return color.Detected()
+}
+
+// withLocation returns a copy of the result with a location set, unless the
+// result already describes its own location.
+func withLocation(result Result, location Location) Result {
+	var details Details
+	if result.Details != nil {
+		details = *result.Details
+	}
+
+	if details.Location.IsZero() {
+		details.Location = location
+	}
+
+	result.Details = &details
+	return result
+}
+
+// failNow ends test execution, first reporting any failures collected so far.
+func (g Ghost) failNow() {
+	if h, ok := g.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	for b := g.batch; b != nil; b = b.parent {
+		g.flush(b)
 	}
+
+	g.t.FailNow()
+}
+
+// flush reports the failures in a batch, returning true if there were none.
+//
+// Failures in a nested batch are reported to the parent batch as one failure.
+func (g Ghost) flush(b *batch) bool {
+	if h, ok := g.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	total, failures, failed := b.drain()
+	if len(failures) == 0 {
+		return !failed
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d of %d assertions failed", len(failures), total)
+	for i, f := range failures {
+		fmt.Fprintf(&sb, "\n\n%d) %s", i+1, f.Details.Location)
+		for _, line := range strings.Split(strings.TrimSpace(f.Message), "\n") {
+			sb.WriteByte('\n')
+			if line != "" {
+				sb.WriteByte('\t')
+				sb.WriteString(line)
+			}
+		}
+	}
+
+	result := Result{
+		Ok:      false,
+		Message: sb.String(),
+		Details: &Details{
+			Name:     "ghost.Collect",
+			Location: b.location,
+			Children: failures,
+		},
+	}
+
+	if b.parent != nil {
+		b.parent.add(result)
+		return false
+	}
+
+	g.reporter.Report(g.t, result)
+	return false
+}
+
+// callerLocation returns the location of the caller of the function calling
+// callerLocation, skipping the number of additional frames specified.
+func callerLocation(skip int) Location {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return Location{}
+	}
+
+	return Location{File: file, Line: line}
+}
+
+// A batch collects the failures of checks run within [Ghost.Collect].
+type batch struct {
+	parent   *batch
+	location Location
+
+	m sync.Mutex
+
+	total    int
+	failures []Result
+	failed   bool
+}
+
+func (b *batch) count() {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.total++
+}
+
+// add records a failed result, which must have its location set.
+func (b *batch) add(result Result) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.failures = append(b.failures, result)
+	b.failed = true
+}
+
+// drain returns the number of checks run and the failures collected since the
+// last drain, as well as whether any check in the batch has ever failed.
+func (b *batch) drain() (int, []Result, bool) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	total, failures := b.total, b.failures
+	b.total, b.failures = 0, nil
+	return total, failures, b.failed
 }
 
 // An Result represents the result of an assertion.
@@ -102,4 +393,63 @@ type Result struct {
 	// A message should be present regardless of whether or not the assertion was
 	// successful.
 	Message string
+
+	// Details optionally describes the assertion in a structured form.
+	//
+	// Details may be nil, as is typical for custom assertions, in which case the
+	// Message is the only description of the assertion available.
+	Details *Details
+}
+
+// Details describes an assertion in a structured form, for consumers that
+// need more than a pre-rendered message.
+//
+// Any field may be left empty if it does not apply to the assertion.
+type Details struct {
+	// Name is the name of the assertion, such as "be.Equal".
+	Name string
+
+	// Args are the source expressions of the arguments passed to the
+	// assertion, as returned by [ghostlib.ArgsFromAST].
+	Args []string
+
+	// Got is the value under test.
+	Got any
+
+	// Want is the value the assertion compared against.
+	Want any
+
+	// Location is where the assertion was checked. It is set by [Ghost] when a
+	// result is checked, if not already present.
+	Location Location
+
+	// Children are the results an assertion was composed from, such as the
+	// results passed to be.All or be.Any.
+	Children []Result
+
+	// Artifacts are the complete values behind a message that was cut short,
+	// such as the full got, want, and diff, keyed by file name. If the
+	// GHOST_ARTIFACT_DIR environment variable is set, they are written to
+	// files when the result is reported.
+	Artifacts map[string]string
+}
+
+// Location is a position in a source file.
+type Location struct {
+	File string
+	Line int
+}
+
+// IsZero returns whether the location is unset.
+func (l Location) IsZero() bool {
+	return l == Location{}
+}
+
+// String returns the location formatted as the base file name and the line.
+func (l Location) String() string {
+	if l.IsZero() {
+		return "unknown location"
+	}
+
+	return fmt.Sprintf("%s:%d", filepath.Base(l.File), l.Line)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/rliebz/ghost"
 	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/color"
 )
 
 func TestGhost_Should(t *testing.T) {
@@ -177,6 +178,189 @@ func TestGhost_NoError(t *testing.T) {
 	g.Should(be.SliceLen(mockT.failNowCalls, 1))
 }
 
+func TestGhost_Collect(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		testG := ghost.New(mockT)
+
+		ok := testG.Collect(func(g ghost.Ghost) {
+			g.Should(be.True(true))
+			g.ShouldNot(be.False(true))
+		})
+
+		g.Should(be.True(ok))
+		g.Should(be.SliceLen(mockT.logCalls, 0))
+		g.Should(be.SliceLen(mockT.failCalls, 0))
+		g.Should(be.SliceLen(mockT.failNowCalls, 0))
+	})
+
+	t.Run("not ok", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		testG := ghost.New(mockT)
+
+		ok := testG.Collect(func(g ghost.Ghost) {
+			g.Should(ghost.Result{Ok: false, Message: "first"})
+			g.Should(ghost.Result{Ok: true, Message: "second"})
+			g.ShouldNot(ghost.Result{Ok: true, Message: "third\nline"})
+		})
+
+		g.Should(be.False(ok))
+		g.Should(be.SliceLen(mockT.failCalls, 2))
+		g.Should(be.SliceLen(mockT.failNowCalls, 0))
+
+		if g.Should(be.SliceLen(mockT.logCalls, 1)) {
+			msg, _ := mockT.logCalls[0][0].(string)
+			g.Should(be.StringMatching(msg, `^2 of 3 assertions failed
+
+1\) ghost_test.go:\d+
+	first
+
+2\) ghost_test.go:\d+
+	third
+	line$`))
+		}
+	})
+
+	t.Run("must", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		testG := ghost.New(mockT)
+
+		testG.Collect(func(g ghost.Ghost) {
+			g.Should(ghost.Result{Ok: false, Message: "first"})
+			g.Must(ghost.Result{Ok: false, Message: "second"})
+		})
+
+		g.Should(be.SliceLen(mockT.failCalls, 2))
+		g.Should(be.SliceLen(mockT.failNowCalls, 1))
+
+		if g.Should(be.SliceLen(mockT.logCalls, 1)) {
+			msg, _ := mockT.logCalls[0][0].(string)
+			g.Should(be.StringMatching(msg, `^2 of 2 assertions failed
+
+1\) ghost_test.go:\d+
+	first
+
+2\) ghost_test.go:\d+
+	second$`))
+		}
+	})
+
+	t.Run("must collect", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		testG := ghost.New(mockT)
+
+		testG.MustCollect(func(g ghost.Ghost) {
+			g.Should(ghost.Result{Ok: false, Message: "first"})
+		})
+
+		g.Should(be.SliceLen(mockT.logCalls, 1))
+		g.Should(be.SliceLen(mockT.failCalls, 1))
+		g.Should(be.SliceLen(mockT.failNowCalls, 1))
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		testG := ghost.New(mockT)
+
+		ok := testG.Collect(func(g ghost.Ghost) {
+			g.Should(ghost.Result{Ok: false, Message: "outer"})
+			g.Collect(func(g ghost.Ghost) {
+				g.Should(ghost.Result{Ok: false, Message: "inner"})
+			})
+		})
+
+		g.Should(be.False(ok))
+
+		if g.Should(be.SliceLen(mockT.logCalls, 1)) {
+			msg, _ := mockT.logCalls[0][0].(string)
+			g.Should(be.StringMatching(msg, `^2 of 2 assertions failed
+
+1\) ghost_test.go:\d+
+	outer
+
+2\) ghost_test.go:\d+
+	1 of 1 assertions failed
+
+	1\) ghost_test.go:\d+
+		inner$`))
+		}
+	})
+}
+
+func TestWithColor(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		testG := ghost.New(mockT, ghost.WithColor(false))
+
+		testG.Should(ghost.Result{
+			Ok: false,
+			Message: `"abcdef" != "abcxef"
+got:  "abc` + color.ANSIGreen + `d` + color.ANSIReset + `ef"
+want: "abc` + color.ANSIRed + `x` + color.ANSIReset + `ef"`,
+			Details: &ghost.Details{Location: ghost.Location{File: "foo_test.go", Line: 12}},
+		})
+
+		g.Should(be.DeepEqual(mockT.logCalls, [][]any{{`"abcdef" != "abcxef"
+got:  "abcdef"
+          ^
+want: "abcxef"
+          ^`}}))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		testG := ghost.New(mockT, ghost.WithColor(true))
+
+		message := "some " + color.ANSIRed + "red" + color.ANSIReset + " message"
+		testG.Should(ghost.Result{
+			Ok:      false,
+			Message: message,
+			Details: &ghost.Details{Location: ghost.Location{File: "foo_test.go", Line: 12}},
+		})
+
+		g.Should(be.DeepEqual(mockT.logCalls, [][]any{{message}}))
+	})
+
+	t.Run("other ghosts", func(t *testing.T) {
+		g := ghost.New(t)
+
+		if !color.Enabled() {
+			t.Skip("colors are not applied with NO_COLOR set")
+		}
+
+		_ = ghost.New(newMockT(), ghost.WithColor(false))
+
+		result := be.Equal("abcdef", "abcxef")
+		g.Should(be.StringContaining(result.Message, "abc"+color.ANSIGreen+"d"))
+	})
+}
+
+func TestLocation(t *testing.T) {
+	g := ghost.New(t)
+
+	loc := ghost.Location{File: "/path/to/file_test.go", Line: 12}
+	g.Should(be.False(loc.IsZero()))
+	g.Should(be.Equal(loc.String(), "file_test.go:12"))
+
+	loc = ghost.Location{}
+	g.Should(be.True(loc.IsZero()))
+	g.Should(be.Equal(loc.String(), "unknown location"))
+}
+
 type mockT struct {
 	m sync.Mutex
 
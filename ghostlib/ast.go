@@ -11,6 +11,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 // ArgsFromAST gets the string representation of the caller's arguments from
@@ -45,32 +46,119 @@ func mapString(s []any) []string {
 }
 
 func callExprArgs(skip int) ([]ast.Expr, error) {
+	call, err := findCallExpr(skip + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return call.node.Args, nil
+}
+
+// A callSite is a call expression found in the caller's source.
+type callSite struct {
+	filename string
+	fset     *token.FileSet
+	node     *ast.CallExpr
+
+	// line is the line of the call as reported by the runtime, which may differ
+	// from the line in the source if the file was rewritten.
+	line int
+}
+
+func findCallExpr(skip int) (callSite, error) {
 	pc, _, _, ok := runtime.Caller(skip)
 	if !ok {
-		return nil, errors.New("failed to get file/line")
+		return callSite{}, errors.New("failed to get file/line")
 	}
 
 	_, filename, line, ok := runtime.Caller(skip + 1)
 	if !ok {
-		return nil, errors.New("failed to get file/line")
+		return callSite{}, errors.New("failed to get file/line")
 	}
 
-	filename = findSystemFilepath(filename)
+	return findCallExprAt(findSystemFilepath(filename), line, runtime.FuncForPC(pc).Name())
+}
+
+// findCallExprAt finds a call to the named function on a line of a file, as
+// reported by the runtime.
+//
+// Since the same assertion is often run many times, such as in a loop or for
+// every check in a report, the result is cached until the file is rewritten.
+func findCallExprAt(filename string, line int, funcName string) (callSite, error) {
+	key := callSiteKey{filename: filename, line: line, funcName: funcName}
+	if cached, ok := callSites.get(key); ok {
+		return cached.site, cached.err
+	}
 
-	wantFunc := runtime.FuncForPC(pc)
+	site, err := parseCallExprAt(filename, line, funcName)
+	callSites.set(key, cachedCallSite{site: site, err: err})
+	return site, err
+}
 
+func parseCallExprAt(filename string, line int, funcName string) (callSite, error) {
 	fset := token.NewFileSet()
 	astFile, err := parser.ParseFile(fset, filename, nil, parser.AllErrors)
 	if err != nil {
-		return nil, err
+		return callSite{}, err
 	}
 
-	node := callExprForFunc(wantFunc, fset, astFile, line)
+	node := callExprForFunc(funcName, fset, astFile, rewrites.currentLine(filename, line))
 	if node == nil {
-		return nil, errors.New("no node found at line")
+		return callSite{}, errors.New("no node found at line")
 	}
 
-	return node.Args, nil
+	return callSite{
+		filename: filename,
+		fset:     fset,
+		node:     node,
+		line:     line,
+	}, nil
+}
+
+// callSites caches the call expressions found by [findCallExprAt].
+var callSites = &callSiteCache{sites: make(map[callSiteKey]cachedCallSite)}
+
+type callSiteKey struct {
+	filename string
+	line     int
+	funcName string
+}
+
+type cachedCallSite struct {
+	site callSite
+	err  error
+}
+
+type callSiteCache struct {
+	m     sync.Mutex
+	sites map[callSiteKey]cachedCallSite
+}
+
+func (c *callSiteCache) get(key callSiteKey) (cachedCallSite, bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	cached, ok := c.sites[key]
+	return cached, ok
+}
+
+func (c *callSiteCache) set(key callSiteKey, cached cachedCallSite) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.sites[key] = cached
+}
+
+// forget removes every call expression cached for a file.
+func (c *callSiteCache) forget(filename string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	for key := range c.sites {
+		if key.filename == filename {
+			delete(c.sites, key)
+		}
+	}
 }
 
 // Passing the -trimpath flag will prevent looking up filepaths directly.
@@ -98,7 +186,7 @@ func findSystemFilepath(filename string) string {
 }
 
 func callExprForFunc(
-	wantFunc *runtime.Func,
+	funcName string,
 	fset *token.FileSet,
 	file *ast.File,
 	lineNum int,
@@ -114,7 +202,7 @@ func callExprForFunc(
 		}
 
 		callExpr, ok := node.(*ast.CallExpr)
-		if ok && describesCallExpr(wantFunc, callExpr) {
+		if ok && describesCallExpr(funcName, callExpr) {
 			out = callExpr
 		}
 
@@ -124,9 +212,8 @@ func callExprForFunc(
 }
 
 // This comparison isn't perfect, but it works well enough so far.
-func describesCallExpr(wantFn *runtime.Func, callExpr *ast.CallExpr) bool {
-	wantName := wantFn.Name()
-	wantName = strings.TrimSuffix(wantName, "[...]")
+func describesCallExpr(funcName string, callExpr *ast.CallExpr) bool {
+	wantName := strings.TrimSuffix(funcName, "[...]")
 	dotIndex := strings.LastIndex(wantName, ".")
 	wantName = wantName[dotIndex+1:]
 
@@ -0,0 +1,152 @@
+package ghostlib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"os"
+	"sync"
+)
+
+// ReplaceArg rewrites the caller's source code, replacing the argument at the
+// given index with a Go expression. This allows assertions such as inline
+// snapshots to update their expected values in place.
+//
+// Only string literals are replaced, since replacing any other expression,
+// such as a variable, could leave the source unable to compile.
+//
+// Like [ArgsFromAST], ReplaceArg must be called directly by the assertion
+// function whose arguments should be replaced.
+func ReplaceArg(index int, expr string) error {
+	call, err := findCallExpr(2)
+	if err != nil {
+		return err
+	}
+
+	return replaceArg(call, index, expr)
+}
+
+func replaceArg(call callSite, index int, expr string) error {
+	formatted, err := format.Source([]byte(expr))
+	if err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+
+	if index < 0 || index >= len(call.node.Args) {
+		return fmt.Errorf("argument %d out of range for %d arguments", index, len(call.node.Args))
+	}
+
+	arg := call.node.Args[index]
+	if lit, ok := arg.(*ast.BasicLit); !ok || lit.Kind != token.STRING {
+		return fmt.Errorf("argument is not a string literal: %s", nodeToString(arg))
+	}
+
+	written := bytes.TrimSpace(formatted)
+
+	// A call that runs more than once, such as in a loop, has its original
+	// argument each time, so it can only be rewritten once.
+	site := rewriteSite{filename: call.filename, line: call.line, index: index}
+	if prev, ok := rewrites.rewritten(site); ok {
+		if prev == string(written) {
+			return nil
+		}
+		return errors.New("argument was already replaced with a different value " +
+			"by an earlier run of the same call")
+	}
+
+	info, err := os.Stat(call.filename)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.ReadFile(call.filename) //nolint:gosec // caller's source file
+	if err != nil {
+		return err
+	}
+
+	start := call.fset.Position(arg.Pos())
+	end := call.fset.Position(arg.End())
+	callStart := call.fset.Position(call.node.Pos())
+
+	var buf bytes.Buffer
+	buf.Write(src[:start.Offset])
+	buf.Write(written)
+	buf.Write(src[end.Offset:])
+
+	if err := os.WriteFile(call.filename, buf.Bytes(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	callSites.forget(call.filename)
+
+	// Arguments on later lines than the start of the call are unaffected by
+	// earlier rewrites, so the original line can be found relative to the call.
+	origLine := call.line + start.Line - callStart.Line
+	delta := bytes.Count(written, []byte("\n")) - (end.Line - start.Line)
+	rewrites.add(site, string(written), origLine, delta)
+
+	return nil
+}
+
+// rewrites tracks changes made to source files by [ReplaceArg], so that line
+// numbers reported by the runtime can still be found after a file changes.
+var rewrites = &rewriteLog{
+	edits: make(map[string][]edit),
+	sites: make(map[rewriteSite]string),
+}
+
+type rewriteLog struct {
+	m     sync.Mutex
+	edits map[string][]edit
+	sites map[rewriteSite]string
+}
+
+// A rewriteSite is an argument of a call that was rewritten, identified by the
+// line of the call as reported by the runtime.
+type rewriteSite struct {
+	filename string
+	line     int
+	index    int
+}
+
+// An edit shifts every line after a line by a number of lines.
+type edit struct {
+	line  int
+	delta int
+}
+
+func (r *rewriteLog) add(site rewriteSite, expr string, line, delta int) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.sites[site] = expr
+	if delta != 0 {
+		r.edits[site.filename] = append(r.edits[site.filename], edit{line: line, delta: delta})
+	}
+}
+
+// rewritten returns the expression an argument was replaced with, if any.
+func (r *rewriteLog) rewritten(site rewriteSite) (string, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	expr, ok := r.sites[site]
+	return expr, ok
+}
+
+// currentLine converts a line from the original source of a file to the
+// matching line in the file as it currently exists.
+func (r *rewriteLog) currentLine(filename string, line int) int {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	out := line
+	for _, e := range r.edits[filename] {
+		if e.line < line {
+			out += e.delta
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package ghostlib
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplaceArg(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("testdata", "snapshot.go.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot_test.go")
+	if err := os.WriteFile(path, src, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lines are those of the original file, as the runtime would report them
+	// after earlier calls have rewritten the file.
+	replace := func(line int, expr string) error {
+		call, err := findCallExprAt(path, line, "github.com/rliebz/ghost/be.Snapshot")
+		if err != nil {
+			return err
+		}
+		return replaceArg(call, 1, expr)
+	}
+
+	for _, tt := range []struct {
+		line int
+		expr string
+	}{
+		{line: 6, expr: "`first\nsecond\nthird`"},
+		{line: 7, expr: `"line"`},
+		{line: 13, expr: "\"done\"\n"}, // trailing newlines are not written
+	} {
+		if err := replace(tt.line, tt.expr); err != nil {
+			t.Fatalf("line %d: %v", tt.line, err)
+		}
+	}
+
+	// Running the same call again is only allowed with the same value.
+	if err := replace(6, "`first\nsecond\nthird`"); err != nil {
+		t.Errorf("unexpected error for repeated rewrite: %v", err)
+	}
+
+	err = replace(6, `"other"`)
+	if err == nil || !strings.Contains(err.Error(), "already replaced") {
+		t.Errorf("unexpected error for conflicting rewrite: %v", err)
+	}
+
+	err = replace(16, `"new"`)
+	if err == nil || err.Error() != "argument is not a string literal: want" {
+		t.Errorf("unexpected error for variable argument: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "snapshot.go.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("rewritten source does not match:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -2,4 +2,11 @@ module github.com/rliebz/ghost
 
 go 1.18
 
-require github.com/google/go-cmp v0.5.9
+require (
+	github.com/google/go-cmp v0.5.9
+	golang.org/x/term v0.27.0
+	golang.org/x/text v0.21.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.28.0 // indirect
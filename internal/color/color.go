@@ -5,7 +5,11 @@ import (
 	"os"
 	"regexp"
 	"runtime"
+	"strings"
 	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/term"
 )
 
 const (
@@ -41,8 +45,82 @@ var (
 	reReset = regexp.MustCompile(`(\033\[0m)(.)`)
 	// reANSI identifies any non-reset ANSI escape sequence.
 	reANSI = regexp.MustCompile(`\033\[[1-9][\d;]*m`)
+	// reAnyANSI identifies any ANSI escape sequence, including resets.
+	reAnyANSI = regexp.MustCompile(`\033\[[\d;]*m`)
+)
+
+// Strip removes any ANSI escape sequences from a string.
+func Strip(s string) string {
+	return reAnyANSI.ReplaceAllString(s, "")
+}
+
+var (
+	// reLeadingANSI identifies an ANSI escape sequence at the start of a string.
+	reLeadingANSI = regexp.MustCompile(`^\033\[[\d;]*m`)
+	// reHighlighted identifies a line showing a quoted got or want value.
+	reHighlighted = regexp.MustCompile(`^\s*(got|want): +(\.\.\.)?"`)
 )
 
+// Plain removes any colors from a message.
+//
+// Where the changed characters of a quoted got or want value are highlighted
+// with color, a line of carets beneath the value marks them instead, the way
+// assertions mark them when colors are not applied.
+func Plain(s string) string {
+	if !reAnyANSI.MatchString(s) {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		plain := Strip(line)
+		out = append(out, plain)
+
+		if !reHighlighted.MatchString(plain) {
+			continue
+		}
+		if marker := markColored(line); marker != "" {
+			out = append(out, marker)
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// markColored returns a line marking the colored characters of a line with
+// carets, keeping any tabs used for indentation. If nothing is colored, the
+// line is empty.
+func markColored(line string) string {
+	var sb strings.Builder
+	var colored bool
+	for line != "" {
+		if seq := reLeadingANSI.FindString(line); seq != "" {
+			colored = seq != ANSIReset
+			line = line[len(seq):]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(line)
+		line = line[size:]
+
+		switch {
+		case colored:
+			sb.WriteByte('^')
+		case r == '\t':
+			sb.WriteByte('\t')
+		default:
+			sb.WriteByte(' ')
+		}
+	}
+
+	marker := strings.TrimRight(sb.String(), " \t")
+	if !strings.Contains(marker, "^") {
+		return ""
+	}
+	return marker
+}
+
 func apply(color string, s string) string {
 	if !Enabled() {
 		return s
@@ -55,12 +133,56 @@ func apply(color string, s string) string {
 	return color + s + ANSIReset
 }
 
-// Enabled returns whether colors are enabled.
+// Enabled returns whether colors are applied, which is the case unless NO_COLOR
+// is set.
+//
+// Colors are applied even where they cannot be shown, so that whether to show
+// them can be decided when a message is reported, such as by using [Detected].
 var Enabled = sync.OnceValue(func() bool {
-	if runtime.GOOS == "windows" {
-		return false
-	}
-
 	_, ok := os.LookupEnv("NO_COLOR")
 	return !ok
 })
+
+// Detected returns whether colors should be shown, based on the environment
+// and whether standard output is a terminal.
+var Detected = sync.OnceValue(func() bool {
+	// Windows consoles do not reliably support ANSI escape sequences.
+	terminal := runtime.GOOS != "windows" && term.IsTerminal(int(os.Stdout.Fd()))
+	return Detect(os.LookupEnv, terminal)
+})
+
+// Detect returns whether colors should be used, given a way to look up
+// environment variables and whether output is a terminal.
+//
+// In order of precedence, colors are:
+//   - disabled if NO_COLOR is set
+//   - disabled if FORCE_COLOR is "0" or "false", and enabled if it is set to
+//     anything else
+//   - enabled if CLICOLOR_FORCE is set to anything other than "0"
+//   - disabled if CLICOLOR is "0" or TERM is "dumb"
+//   - enabled if output is a terminal
+//
+// Otherwise, colors are disabled.
+func Detect(lookupEnv func(key string) (string, bool), terminal bool) bool {
+	if _, ok := lookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	if force, ok := lookupEnv("FORCE_COLOR"); ok && force != "" {
+		return force != "0" && force != "false"
+	}
+
+	if force, ok := lookupEnv("CLICOLOR_FORCE"); ok && force != "" && force != "0" {
+		return true
+	}
+
+	if cli, ok := lookupEnv("CLICOLOR"); ok && cli == "0" {
+		return false
+	}
+
+	if t, ok := lookupEnv("TERM"); ok && t == "dumb" {
+		return false
+	}
+
+	return terminal
+}
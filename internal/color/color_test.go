@@ -0,0 +1,112 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/color"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		terminal bool
+		want     bool
+	}{
+		{name: "terminal", terminal: true, want: true},
+		{name: "not a terminal", terminal: false, want: false},
+		{name: "no color", env: map[string]string{"NO_COLOR": ""}, terminal: true, want: false},
+		{
+			name:     "no color before force color",
+			env:      map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"},
+			terminal: true,
+			want:     false,
+		},
+		{name: "force color", env: map[string]string{"FORCE_COLOR": "1"}, want: true},
+		{
+			name:     "force color zero",
+			env:      map[string]string{"FORCE_COLOR": "0"},
+			terminal: true,
+			want:     false,
+		},
+		{
+			name:     "force color false",
+			env:      map[string]string{"FORCE_COLOR": "false"},
+			terminal: true,
+			want:     false,
+		},
+		{name: "force color empty", env: map[string]string{"FORCE_COLOR": ""}, want: false},
+		{name: "clicolor force", env: map[string]string{"CLICOLOR_FORCE": "1"}, want: true},
+		{name: "clicolor force zero", env: map[string]string{"CLICOLOR_FORCE": "0"}, want: false},
+		{
+			name:     "clicolor force before term",
+			env:      map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb"},
+			terminal: false,
+			want:     true,
+		},
+		{name: "clicolor zero", env: map[string]string{"CLICOLOR": "0"}, terminal: true, want: false},
+		{name: "clicolor one", env: map[string]string{"CLICOLOR": "1"}, terminal: true, want: true},
+		{name: "dumb terminal", env: map[string]string{"TERM": "dumb"}, terminal: true, want: false},
+		{name: "other terminal", env: map[string]string{"TERM": "xterm"}, terminal: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			lookupEnv := func(key string) (string, bool) {
+				v, ok := tt.env[key]
+				return v, ok
+			}
+
+			g.Should(be.Equal(color.Detect(lookupEnv, tt.terminal), tt.want))
+		})
+	}
+}
+
+func TestPlain(t *testing.T) {
+	green := func(s string) string { return color.ANSIGreen + s + color.ANSIReset }
+	red := func(s string) string { return color.ANSIRed + s + color.ANSIReset }
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "no color",
+			message: "got:  \"abc\"\nwant: \"abd\"",
+			want:    "got:  \"abc\"\nwant: \"abd\"",
+		},
+		{
+			name:    "highlighted",
+			message: "got:  \"a" + green("b") + "c" + green("de") + "\"\nwant: \"a" + red("x") + "c\"",
+			want:    "got:  \"abcde\"\n        ^ ^^\nwant: \"axc\"\n        ^",
+		},
+		{
+			name:    "cut short",
+			message: "got:  ...\"a" + green("b") + "\" ... (3 more characters omitted)",
+			want:    "got:  ...\"ab\" ... (3 more characters omitted)\n           ^",
+		},
+		{
+			name:    "indented",
+			message: "\tgot:  \"" + green("a") + "\"",
+			want:    "\tgot:  \"a\"\n\t       ^",
+		},
+		{
+			name:    "other lines",
+			message: "diff (-want +got):\n" + red("-foo") + "\n" + green("+bar"),
+			want:    "diff (-want +got):\n-foo\n+bar",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			g.Should(be.Equal(color.Plain(tt.message), tt.want))
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Package decode converts YAML and XML documents to the values produced by
+// decoding JSON, so that they can be compared with package jsondiff.
+package decode
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML decodes YAML-encoded data containing at most one document.
+//
+// Mapping keys are converted to strings, numbers to [json.Number], and
+// timestamps to RFC 3339 strings.
+func YAML(data []byte) (any, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	var v any
+	if err := dec.Decode(&v); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var next any
+	switch err := dec.Decode(&next); {
+	case err == nil:
+		return nil, errors.New("yaml: multiple documents are not supported")
+	case !errors.Is(err, io.EOF):
+		return nil, err
+	}
+
+	return normalizeYAML(v), nil
+}
+
+func normalizeYAML(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, vv := range v {
+			out[k] = normalizeYAML(vv)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for k, vv := range v {
+			out[fmt.Sprint(k)] = normalizeYAML(vv)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, vv := range v {
+			out[i] = normalizeYAML(vv)
+		}
+		return out
+	case int:
+		return json.Number(strconv.Itoa(v))
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10))
+	case *big.Int:
+		return json.Number(v.String())
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64))
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return string(v)
+	default:
+		return v
+	}
+}
+
+// XML decodes XML-encoded data with a single root element.
+//
+// Each element becomes an object, with attributes prefixed by "@". Child
+// elements and the text between them are listed in document order under
+// "#children", where each element is an object keyed by its name and each
+// text node is a string with surrounding whitespace removed. Text nodes made
+// up of whitespace are omitted. An element without children has its text
+// under "#text" instead, and an element with neither attributes nor children
+// becomes its text. The root element is wrapped in an object keyed by its
+// name.
+//
+// Namespaced names are written as "{namespace}local", and namespace
+// declarations are omitted.
+func XML(data []byte) (any, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var root any
+	var rootName string
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if root != nil {
+				return nil, errors.New("xml: multiple root elements")
+			}
+			rootName = xmlName(tok.Name)
+			root, err = decodeElement(dec, tok)
+			if err != nil {
+				return nil, err
+			}
+		case xml.CharData:
+			if len(bytes.TrimSpace(tok)) > 0 {
+				return nil, errors.New("xml: text outside of the root element")
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("xml: no root element")
+	}
+
+	return map[string]any{rootName: root}, nil
+}
+
+// xmlElement collects the contents of an element as it is decoded.
+type xmlElement struct {
+	attrs    map[string]any
+	children []any
+	elements int
+
+	// text is the text since the last child element, which may be split
+	// across tokens by comments or character references.
+	text strings.Builder
+}
+
+// flushText adds any text since the last child element as a child.
+func (e *xmlElement) flushText() {
+	if text := strings.TrimSpace(e.text.String()); text != "" {
+		e.children = append(e.children, text)
+	}
+	e.text.Reset()
+}
+
+func decodeElement(dec *xml.Decoder, start xml.StartElement) (any, error) {
+	e := &xmlElement{attrs: make(map[string]any)}
+
+	for _, attr := range start.Attr {
+		if isNamespaceDecl(attr.Name) {
+			continue
+		}
+		e.attrs["@"+xmlName(attr.Name)] = attr.Value
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			e.flushText()
+			child, err := decodeElement(dec, tok)
+			if err != nil {
+				return nil, err
+			}
+			e.children = append(e.children, map[string]any{xmlName(tok.Name): child})
+			e.elements++
+		case xml.CharData:
+			e.text.Write(tok)
+		case xml.EndElement:
+			e.flushText()
+			return e.value(), nil
+		}
+	}
+}
+
+func (e *xmlElement) value() any {
+	if e.elements > 0 {
+		e.attrs["#children"] = e.children
+		return e.attrs
+	}
+
+	var text string
+	if len(e.children) > 0 {
+		text = e.children[0].(string)
+	}
+
+	if len(e.attrs) == 0 {
+		return text
+	}
+
+	if text != "" {
+		e.attrs["#text"] = text
+	}
+	return e.attrs
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+func isNamespaceDecl(name xml.Name) bool {
+	return name.Space == "xmlns" || name.Space == "" && name.Local == "xmlns"
+}
@@ -0,0 +1,176 @@
+package decode_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/decode"
+)
+
+func TestYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want any
+	}{
+		{
+			name: "scalars",
+			in:   "[1, 1.5, true, null, foo, '2']",
+			want: []any{json.Number("1"), json.Number("1.5"), true, nil, "foo", "2"},
+		},
+		{
+			name: "mapping",
+			in:   "b: 1\na:\n  - x\n  - y\n",
+			want: map[string]any{
+				"a": []any{"x", "y"},
+				"b": json.Number("1"),
+			},
+		},
+		{
+			name: "non-string keys",
+			in:   "1: one\ntrue: yes\n",
+			want: map[string]any{"1": "one", "true": "yes"},
+		},
+		{
+			name: "timestamp",
+			in:   "at: 2024-01-02T03:04:05Z",
+			want: map[string]any{"at": "2024-01-02T03:04:05Z"},
+		},
+		{
+			name: "empty",
+			in:   "",
+			want: nil,
+		},
+		{
+			name: "document marker",
+			in:   "---\na: 1\n",
+			want: map[string]any{"a": json.Number("1")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			got, err := decode.YAML([]byte(tt.in))
+			g.NoError(err)
+			g.Should(be.DeepEqual(got, tt.want))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		g := ghost.New(t)
+
+		_, err := decode.YAML([]byte("a: ["))
+		g.Should(be.Error(err))
+	})
+
+	t.Run("multiple documents", func(t *testing.T) {
+		g := ghost.New(t)
+
+		_, err := decode.YAML([]byte("a: 1\n---\nb: 2\n"))
+		g.Should(be.ErrorEqual(err, "yaml: multiple documents are not supported"))
+	})
+
+	t.Run("invalid second document", func(t *testing.T) {
+		g := ghost.New(t)
+
+		_, err := decode.YAML([]byte("a: 1\n---\nb: ["))
+		g.Should(be.Error(err))
+	})
+}
+
+func TestXML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want any
+	}{
+		{
+			name: "text",
+			in:   `<?xml version="1.0"?><name> alice </name>`,
+			want: map[string]any{"name": "alice"},
+		},
+		{
+			name: "attributes and children",
+			in: `<user id="1">
+  <!-- comment -->
+  <name>alice</name>
+  <role>admin</role>
+  <role>dev</role>
+  <empty/>
+</user>`,
+			want: map[string]any{
+				"user": map[string]any{
+					"@id": "1",
+					"#children": []any{
+						map[string]any{"name": "alice"},
+						map[string]any{"role": "admin"},
+						map[string]any{"role": "dev"},
+						map[string]any{"empty": ""},
+					},
+				},
+			},
+		},
+		{
+			name: "attributes and text",
+			in:   `<name lang="en"> alice </name>`,
+			want: map[string]any{
+				"name": map[string]any{"@lang": "en", "#text": "alice"},
+			},
+		},
+		{
+			name: "mixed content",
+			in:   `<p lang="en">hello <b>world</b> again<!-- comment -->!</p>`,
+			want: map[string]any{
+				"p": map[string]any{
+					"@lang": "en",
+					"#children": []any{
+						"hello",
+						map[string]any{"b": "world"},
+						"again!",
+					},
+				},
+			},
+		},
+		{
+			name: "namespaces",
+			in:   `<a:root xmlns:a="urn:a" a:id="1"/>`,
+			want: map[string]any{
+				"{urn:a}root": map[string]any{"@{urn:a}id": "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			got, err := decode.XML([]byte(tt.in))
+			g.NoError(err)
+			g.Should(be.DeepEqual(got, tt.want))
+		})
+	}
+
+	invalid := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "empty", in: ``, want: "xml: no root element"},
+		{name: "multiple roots", in: `<a/><b/>`, want: "xml: multiple root elements"},
+		{name: "outside text", in: `<a/>b`, want: "xml: text outside of the root element"},
+		{name: "unclosed", in: `<a>`, want: "XML syntax error on line 1: unexpected EOF"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			_, err := decode.XML([]byte(tt.in))
+			g.Should(be.ErrorEqual(err, tt.want))
+		})
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/rliebz/ghost/internal/color"
+	"github.com/rliebz/ghost/internal/limits"
 )
 
 // Kind describes the result of the diff categorically.
@@ -42,10 +45,70 @@ func (k Kind) String() string {
 	}
 }
 
+// Options customize how JSON values are compared.
+type Options struct {
+	// Subset allows objects in got to have keys that are not in want. Keys that
+	// are only in got are left out of the diff.
+	Subset bool
+
+	// SubsetArrays allows arrays in got to have elements that are not in want,
+	// as long as each element of want appears in got in the same order.
+	SubsetArrays bool
+
+	// UnorderedArrays matches the elements of arrays regardless of order.
+	UnorderedArrays bool
+
+	// UnorderedPaths are JSON Pointers to arrays whose elements are matched
+	// regardless of order.
+	UnorderedPaths []string
+
+	// IgnorePaths are JSON Pointers to values that are not compared.
+	IgnorePaths []string
+
+	// NumericNumbers compares numbers by value rather than by their encoding,
+	// so that 1.0 and 1 are equal.
+	NumericNumbers bool
+
+	// NumberTolerance is the largest difference between two numbers for them
+	// to be equal. If set, numbers are compared by value.
+	NumberTolerance float64
+
+	// NullAsMissing treats object keys with a null value the same as keys that
+	// are not present.
+	NullAsMissing bool
+
+	// Summary lists the JSON Pointer path of every difference before the diff.
+	Summary bool
+
+	// MaxDifferences is the most differences listed in the summary. If it is
+	// 0, every difference is listed.
+	MaxDifferences int
+
+	// Collapse replaces runs of unchanged elements with a count, leaving only
+	// the parts of the diff that differ.
+	Collapse bool
+}
+
 // Diff returns a pretty JSON diff of two inputs.
 func Diff[T ~string | ~[]byte](got, want T) (string, Kind) {
-	gotValue, gotErr := decode(got)
-	wantValue, wantErr := decode(want)
+	return DiffWith(got, want, Options{})
+}
+
+// DiffWith returns a pretty JSON diff of two inputs, compared using options.
+func DiffWith[T ~string | ~[]byte](got, want T, opts Options) (string, Kind) {
+	return DiffDecoded(got, want, Decode[[]byte], opts)
+}
+
+// DiffDecoded returns a pretty JSON diff of two inputs in another format,
+// which decode converts to the values produced by [Decode].
+func DiffDecoded[T ~string | ~[]byte](
+	got T,
+	want T,
+	decode func([]byte) (any, error),
+	opts Options,
+) (string, Kind) {
+	gotValue, gotErr := decode([]byte(got))
+	wantValue, wantErr := decode([]byte(want))
 
 	switch {
 	case gotErr != nil && wantErr != nil:
@@ -56,12 +119,20 @@ func Diff[T ~string | ~[]byte](got, want T) (string, Kind) {
 		return "", WantInvalid
 	}
 
-	d := newDiffer()
+	d := newDiffer(opts)
 	d.diffValues(gotValue, wantValue)
+
+	// A difference at the root has no path worth listing.
+	if opts.Summary && len(d.diffs) > 0 && d.diffs[0].pointer != "" {
+		return d.summary() + "\n\n" + d.buf.String(), d.kind
+	}
+
 	return d.buf.String(), d.kind
 }
 
-func decode[T ~string | ~[]byte](v T) (any, error) {
+// Decode decodes JSON-encoded data, keeping numbers as [json.Number] so that
+// they are not rounded.
+func Decode[T ~string | ~[]byte](v T) (any, error) {
 	dec := json.NewDecoder(bytes.NewReader([]byte(v)))
 	dec.UseNumber()
 
@@ -75,17 +146,85 @@ type differ struct {
 	kind   Kind
 	level  int
 	prefix byte
+	opts   Options
+
+	// diffs are the differences found so far, for the summary.
+	diffs []difference
+
+	// path holds the reference tokens to the current value.
+	path      []string
+	ignore    map[string]bool
+	unordered map[string]bool
 }
 
-func newDiffer() *differ {
+func newDiffer(opts Options) *differ {
 	return &differ{
-		buf:    new(bytes.Buffer),
-		level:  1, // start non-zero to make space for +/-/~
-		prefix: ' ',
+		buf:       new(bytes.Buffer),
+		level:     1, // start non-zero to make space for +/-/~
+		prefix:    ' ',
+		opts:      opts,
+		ignore:    stringSet(opts.IgnorePaths),
+		unordered: stringSet(opts.UnorderedPaths),
+	}
+}
+
+func stringSet(ss []string) map[string]bool {
+	set := make(map[string]bool, len(ss))
+	for _, s := range ss {
+		set[s] = true
+	}
+	return set
+}
+
+// matches reports whether two values match, without writing anything.
+func (d *differ) matches(got, want any) bool {
+	sub := newDiffer(d.opts)
+	sub.path = append([]string(nil), d.path...)
+	sub.diffValues(got, want)
+	return sub.kind == Match
+}
+
+func (d *differ) push(token string) {
+	d.path = append(d.path, token)
+}
+
+func (d *differ) pop() {
+	d.path = d.path[:len(d.path)-1]
+}
+
+// pointer returns the JSON Pointer to the current value, as in RFC 6901.
+func (d *differ) pointer() string {
+	var sb strings.Builder
+	for _, token := range d.path {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(token))
 	}
+	return sb.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func (d *differ) ignored() bool {
+	return len(d.ignore) > 0 && d.ignore[d.pointer()]
 }
 
 func (d *differ) diffValues(got, want any) {
+	if d.ignored() {
+		d.writeIgnored()
+		return
+	}
+
+	gotNumber, gotOk := got.(json.Number)
+	wantNumber, wantOk := want.(json.Number)
+	if gotOk && wantOk && (d.opts.NumericNumbers || d.opts.NumberTolerance > 0) {
+		if !d.numbersEqual(gotNumber, wantNumber) {
+			d.writeMismatch(got, want)
+			return
+		}
+		d.writeValue(got)
+		return
+	}
+
 	switch {
 	case got == nil && want == nil:
 		d.writeValue(got)
@@ -127,7 +266,7 @@ func (d *differ) diffValues(got, want any) {
 }
 
 func (d *differ) diffMaps(got, want map[string]any) {
-	if len(got) == 0 && len(want) == 0 {
+	if len(want) == 0 && (len(got) == 0 || d.opts.Subset) {
 		d.buf.WriteString("{}")
 		return
 	}
@@ -153,43 +292,66 @@ func (d *differ) diffMaps(got, want map[string]any) {
 	}
 	sort.Strings(sortedKeys)
 
-	first := true
+	els := d.newElements()
 	for _, k := range sortedKeys {
-		gotValue, gotOk := got[k]
-		wantValue, wantOk := want[k]
-
-		switch {
-		case gotOk && wantOk:
-			d.writeElementSeparator(first)
-			fmt.Fprintf(d.buf, "%q: ", k)
-			d.diffValues(gotValue, wantValue)
-		case gotOk:
-			d.failMatch()
-			d.prefix = '+'
-			d.writeElementSeparator(first)
-			fmt.Fprintf(d.buf, "%q: ", k)
-			d.writeValue(gotValue)
-			d.prefix = ' '
-		case wantOk:
-			d.failMatch()
-			d.prefix = '-'
-			d.writeElementSeparator(first)
-			fmt.Fprintf(d.buf, "%q: ", k)
-			d.writeValue(wantValue)
-			d.prefix = ' '
-		default:
-			panic(fmt.Sprintf("unexpected key %q in map; this is a ghost error", k))
-		}
-
-		first = false
+		d.push(k)
+		d.diffMapEntry(els, k, got, want)
+		d.pop()
 	}
+	els.flush()
+
 	d.level--
 	d.writeNewlineIndent()
 	d.buf.WriteByte('}')
 }
 
+// diffMapEntry diffs the values for a key in two maps.
+func (d *differ) diffMapEntry(els *elements, k string, got, want map[string]any) {
+	gotValue, gotOk := got[k]
+	wantValue, wantOk := want[k]
+
+	switch {
+	case gotOk && !wantOk && d.opts.Subset:
+		return
+	case d.ignored():
+		d.writeElementSeparator(els.next())
+		fmt.Fprintf(d.buf, "%q: ", k)
+		d.writeIgnored()
+	case gotOk && wantOk && d.collapsible(gotValue, wantValue):
+		els.skip()
+	case gotOk && wantOk:
+		d.writeElementSeparator(els.next())
+		fmt.Fprintf(d.buf, "%q: ", k)
+		d.diffValues(gotValue, wantValue)
+	case d.opts.NullAsMissing && gotValue == nil && wantValue == nil:
+		if d.opts.Collapse {
+			els.skip()
+			return
+		}
+		d.writeElementSeparator(els.next())
+		fmt.Fprintf(d.buf, "%q: ", k)
+		d.writeValue(nil)
+	case gotOk:
+		d.record('+', inline(gotValue))
+		d.prefix = '+'
+		d.writeElementSeparator(els.next())
+		fmt.Fprintf(d.buf, "%q: ", k)
+		d.writeValue(gotValue)
+		d.prefix = ' '
+	case wantOk:
+		d.record('-', inline(wantValue))
+		d.prefix = '-'
+		d.writeElementSeparator(els.next())
+		fmt.Fprintf(d.buf, "%q: ", k)
+		d.writeValue(wantValue)
+		d.prefix = ' '
+	default:
+		panic(fmt.Sprintf("unexpected key %q in map; this is a ghost error", k))
+	}
+}
+
 func (d *differ) diffSlices(got, want []any) {
-	if len(got) == 0 && len(want) == 0 {
+	if len(want) == 0 && (len(got) == 0 || d.opts.SubsetArrays) {
 		d.buf.WriteString("[]")
 		return
 	}
@@ -200,33 +362,297 @@ func (d *differ) diffSlices(got, want []any) {
 
 	d.buf.WriteByte('[')
 	d.level++
+
+	switch {
+	case d.opts.UnorderedArrays, d.unordered[d.pointer()]:
+		d.diffSlicesUnordered(got, want)
+	case d.opts.SubsetArrays:
+		d.diffSlicesSubsequence(got, want)
+	default:
+		d.diffSlicesOrdered(got, want)
+	}
+
+	d.level--
+	d.writeNewlineIndent()
+	d.buf.WriteByte(']')
+}
+
+func (d *differ) diffSlicesOrdered(got, want []any) {
+	els := d.newElements()
 	for i := 0; i < len(got) || i < len(want); i++ {
+		d.push(strconv.Itoa(i))
 		switch {
+		case d.ignored():
+			d.writeElementSeparator(els.next())
+			d.writeIgnored()
 		case i >= len(got):
-			d.failMatch()
-			d.prefix = '-'
-			d.writeExtraSliceElements(&i, want)
-			d.prefix = ' '
+			d.writeMissing(els.next(), want[i])
 		case i >= len(want):
-			d.failMatch()
-			d.prefix = '+'
-			d.writeExtraSliceElements(&i, got)
-			d.prefix = ' '
+			d.writeExtra(els.next(), got[i])
+		case d.collapsible(got[i], want[i]):
+			els.skip()
 		default:
-			d.writeElementSeparator(i == 0)
+			d.writeElementSeparator(els.next())
 			d.diffValues(got[i], want[i])
 		}
+		d.pop()
 	}
-	d.level--
-	d.writeNewlineIndent()
-	d.buf.WriteByte(']')
+	els.flush()
+}
+
+// diffSlicesSubsequence diffs slices where every element of want must appear
+// in got in the same order, but got may have other elements in between.
+func (d *differ) diffSlicesSubsequence(got, want []any) {
+	els := d.newElements()
+
+	// Matching greedily is enough, since the earliest match for each element
+	// leaves the most options for the elements after it.
+	j := 0
+	for i, w := range want {
+		d.push(strconv.Itoa(i))
+
+		k := j
+		for k < len(got) && !d.matches(got[k], w) {
+			k++
+		}
+
+		switch {
+		case k == len(got):
+			d.writeMissing(els.next(), w)
+		case d.collapsible(got[k], w):
+			els.skip()
+			j = k + 1
+		default:
+			d.writeElementSeparator(els.next())
+			d.diffValues(got[k], w)
+			j = k + 1
+		}
+
+		d.pop()
+	}
+	els.flush()
+}
+
+// diffSlicesUnordered diffs slices where elements may appear in any order.
+// Elements of want are listed first in their original order, followed by any
+// elements of got that were not matched.
+func (d *differ) diffSlicesUnordered(got, want []any) {
+	els := d.newElements()
+
+	matched := d.matchElements(got, want)
+
+	used := make([]bool, len(got))
+	for i, w := range want {
+		d.push(strconv.Itoa(i))
+		j := matched[i]
+		switch {
+		case j == -1:
+			d.writeMissing(els.next(), w)
+		case d.collapsible(got[j], w):
+			used[j] = true
+			els.skip()
+		default:
+			used[j] = true
+			d.writeElementSeparator(els.next())
+			d.diffValues(got[j], w)
+		}
+		d.pop()
+	}
+
+	if !d.opts.SubsetArrays {
+		for j, u := range used {
+			if !u {
+				d.push(strconv.Itoa(j))
+				d.writeExtra(els.next(), got[j])
+				d.pop()
+			}
+		}
+	}
+
+	els.flush()
+}
+
+// matchElements pairs each element of want with a distinct matching element
+// of got, returning the index in got for each element of want, or -1.
+//
+// Since matching is not always an equivalence, such as when objects in want
+// may be subsets, this finds a maximum bipartite matching using augmenting
+// paths rather than taking the first match for each element.
+func (d *differ) matchElements(got, want []any) []int {
+	edges := make([][]int, len(want))
+	for i, w := range want {
+		d.push(strconv.Itoa(i))
+		for j, g := range got {
+			if d.matches(g, w) {
+				edges[i] = append(edges[i], j)
+			}
+		}
+		d.pop()
+	}
+
+	wantFor := make([]int, len(got))
+	for j := range wantFor {
+		wantFor[j] = -1
+	}
+
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for _, j := range edges[i] {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+
+			if wantFor[j] == -1 || augment(wantFor[j], seen) {
+				wantFor[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range want {
+		augment(i, make([]bool, len(got)))
+	}
+
+	gotFor := make([]int, len(want))
+	for i := range gotFor {
+		gotFor[i] = -1
+	}
+	for j, i := range wantFor {
+		if i != -1 {
+			gotFor[i] = j
+		}
+	}
+
+	return gotFor
+}
+
+// collapsible reports whether a pair of values can be collapsed because they
+// are unchanged.
+func (d *differ) collapsible(got, want any) bool {
+	return d.opts.Collapse && d.matches(got, want)
+}
+
+// elements tracks what has been written to a collection, so that runs of
+// unchanged elements can be collapsed.
+type elements struct {
+	d         *differ
+	first     bool
+	unchanged int
+}
+
+func (d *differ) newElements() *elements {
+	return &elements{d: d, first: true}
+}
+
+// skip collapses an unchanged element.
+func (e *elements) skip() {
+	e.unchanged++
 }
 
-func (d *differ) writeExtraSliceElements(idx *int, s []any) {
-	for ; *idx < len(s); *idx++ {
-		d.writeElementSeparator(*idx == 0)
-		d.writeValue(s[*idx])
+// next writes any collapsed elements, and reports whether the element about
+// to be written is the first.
+func (e *elements) next() bool {
+	e.flush()
+	first := e.first
+	e.first = false
+	return first
+}
+
+// flush writes a placeholder for any collapsed elements.
+func (e *elements) flush() {
+	if e.unchanged == 0 {
+		return
 	}
+
+	e.d.writeElementSeparator(e.first)
+	fmt.Fprintf(e.d.buf, "... (%d unchanged)", e.unchanged)
+	e.first = false
+	e.unchanged = 0
+}
+
+// writeMissing writes an element of want that has no match in got.
+func (d *differ) writeMissing(first bool, v any) {
+	d.record('-', inline(v))
+	d.prefix = '-'
+	d.writeElementSeparator(first)
+	d.writeValue(v)
+	d.prefix = ' '
+}
+
+// writeExtra writes an element of got that has no match in want.
+func (d *differ) writeExtra(first bool, v any) {
+	d.record('+', inline(v))
+	d.prefix = '+'
+	d.writeElementSeparator(first)
+	d.writeValue(v)
+	d.prefix = ' '
+}
+
+// record notes a difference at the current path for the summary, and marks
+// the diff as failing.
+func (d *differ) record(prefix byte, text string) {
+	d.failMatch()
+	d.diffs = append(d.diffs, difference{
+		pointer: d.pointer(),
+		prefix:  prefix,
+		text:    text,
+	})
+}
+
+// A difference is a single difference found at a path.
+type difference struct {
+	pointer string
+	prefix  byte
+	text    string
+}
+
+// summary lists the path of every difference.
+func (d *differ) summary() string {
+	lines := make([]string, 0, len(d.diffs))
+	for i, diff := range d.diffs {
+		if limits.Exceeded(i+1, d.opts.MaxDifferences) {
+			lines = append(lines, limits.Omitted(len(d.diffs)-i, "difference", "differences"))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%c %s: %s", diff.prefix, diff.pointer, diff.text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inline formats a value on a single line.
+func inline(v any) string {
+	d := &differ{buf: new(bytes.Buffer)}
+	d.writeValueInline(v)
+	return d.buf.String()
+}
+
+// writeIgnored marks a value that was not compared.
+func (d *differ) writeIgnored() {
+	d.writeANSI(color.ANSIYellow)
+	d.buf.WriteString("<ignored>")
+	d.writeANSI(color.ANSIReset)
+}
+
+// numbersEqual compares two numbers by value, within any tolerance.
+func (d *differ) numbersEqual(got, want json.Number) bool {
+	if d.opts.NumberTolerance > 0 {
+		gotFloat, gotErr := got.Float64()
+		wantFloat, wantErr := want.Float64()
+		if gotErr != nil || wantErr != nil {
+			return got == want
+		}
+		return math.Abs(gotFloat-wantFloat) <= d.opts.NumberTolerance
+	}
+
+	// Rationals compare exactly, even for numbers a float64 cannot represent.
+	gotRat, gotOk := new(big.Rat).SetString(string(got))
+	wantRat, wantOk := new(big.Rat).SetString(string(want))
+	if !gotOk || !wantOk {
+		return got == want
+	}
+	return gotRat.Cmp(wantRat) == 0
 }
 
 func (d *differ) writeValue(v any) {
@@ -339,7 +765,7 @@ func (d *differ) writeMismatch(got, want any) {
 	d.writeValueInline(got)
 	d.writeANSI(color.ANSIReset)
 
-	d.failMatch()
+	d.record('~', inline(want)+" => "+inline(got))
 }
 
 func (d *differ) writeANSI(sequence string) {
@@ -452,3 +452,393 @@ func TestKind_String(t *testing.T) {
 	k := jsondiff.Kind(-1)
 	g.Should(be.Equal(k.String(), "InvalidKind"))
 }
+
+func TestDiffWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		opts     jsondiff.Options
+		wantDiff string
+		wantKind jsondiff.Kind
+	}{
+		// Subsets
+		{
+			name:     "subset empty object",
+			a:        `{"a": 1}`,
+			b:        `{}`,
+			opts:     jsondiff.Options{Subset: true},
+			wantDiff: `{}`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "subset extra keys",
+			a:    `{"a": 1, "b": 2, "c": {"d": 3, "e": 4}}`,
+			b:    `{"b": 2, "c": {"e": 4}}`,
+			opts: jsondiff.Options{Subset: true},
+			wantDiff: `  {
+    "b": 2,
+    "c": {
+      "e": 4
+    }
+  }`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "subset missing keys",
+			a:    `{"a": 1, "b": 2}`,
+			b:    `{"b": 3, "c": 4}`,
+			opts: jsondiff.Options{Subset: true},
+			wantDiff: `  {
+~   "b": 3 => 2,
+-   "c": 4
+  }`,
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name: "subset arrays exact length",
+			a:    `[{"a": 1, "b": 2}, 3]`,
+			b:    `[{"a": 1}]`,
+			opts: jsondiff.Options{Subset: true},
+			wantDiff: `  [
+    {
+      "a": 1
+    },
++   3
+  ]`,
+			wantKind: jsondiff.NoMatch,
+		},
+
+		// Subset arrays
+		{
+			name:     "subset arrays empty",
+			a:        `[1, 2]`,
+			b:        `[]`,
+			opts:     jsondiff.Options{SubsetArrays: true},
+			wantDiff: `[]`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "subset arrays in order",
+			a:    `[1, 2, 3, 4]`,
+			b:    `[2, 4]`,
+			opts: jsondiff.Options{SubsetArrays: true},
+			wantDiff: `  [
+    2,
+    4
+  ]`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "subset arrays out of order",
+			a:    `[1, 2, 3, 4]`,
+			b:    `[4, 2]`,
+			opts: jsondiff.Options{SubsetArrays: true},
+			wantDiff: `  [
+    4,
+-   2
+  ]`,
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name: "subset arrays of objects",
+			a:    `[{"id": 1, "x": true}, {"id": 2, "x": false}]`,
+			b:    `[{"id": 2}]`,
+			opts: jsondiff.Options{Subset: true, SubsetArrays: true},
+			wantDiff: `  [
+    {
+      "id": 2
+    }
+  ]`,
+			wantKind: jsondiff.Match,
+		},
+
+		// Unordered arrays
+		{
+			name: "unordered arrays equal",
+			a:    `[1, 2, 2, 3]`,
+			b:    `[2, 3, 1, 2]`,
+			opts: jsondiff.Options{UnorderedArrays: true},
+			wantDiff: `  [
+    2,
+    3,
+    1,
+    2
+  ]`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "unordered arrays not equal",
+			a:    `[1, 2, 4]`,
+			b:    `[3, 2, 1]`,
+			opts: jsondiff.Options{UnorderedArrays: true},
+			wantDiff: `  [
+-   3,
+    2,
+    1,
++   4
+  ]`,
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name: "unordered subset arrays",
+			a:    `[{"a": 1, "b": 2}, {"a": 1}, {"c": 3}]`,
+			b:    `[{"a": 1}, {"a": 1, "b": 2}]`,
+			opts: jsondiff.Options{Subset: true, SubsetArrays: true, UnorderedArrays: true},
+			wantDiff: `  [
+    {
+      "a": 1
+    },
+    {
+      "a": 1,
+      "b": 2
+    }
+  ]`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "unordered subset arrays missing",
+			a:    `[1, 2]`,
+			b:    `[2, 3]`,
+			opts: jsondiff.Options{SubsetArrays: true, UnorderedArrays: true},
+			wantDiff: `  [
+    2,
+-   3
+  ]`,
+			wantKind: jsondiff.NoMatch,
+		},
+
+		// Unordered paths
+		{
+			name: "unordered path",
+			a:    `{"a": [1, 2], "b": [1, 2]}`,
+			b:    `{"a": [2, 1], "b": [2, 1]}`,
+			opts: jsondiff.Options{UnorderedPaths: []string{"/a"}},
+			wantDiff: `  {
+    "a": [
+      2,
+      1
+    ],
+    "b": [
+~     2 => 1,
+~     1 => 2
+    ]
+  }`,
+			wantKind: jsondiff.NoMatch,
+		},
+
+		// Ignored paths
+		{
+			name: "ignore object key",
+			a:    `{"meta": {"requestId": "abc", "page": 1}, "data": [1]}`,
+			b:    `{"meta": {"requestId": "xyz", "page": 1}, "data": [1]}`,
+			opts: jsondiff.Options{IgnorePaths: []string{"/meta/requestId"}},
+			wantDiff: `  {
+    "data": [
+      1
+    ],
+    "meta": {
+      "page": 1,
+      "requestId": <ignored>
+    }
+  }`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "ignore missing key",
+			a:    `{"a": 1, "id": 7}`,
+			b:    `{"a": 1}`,
+			opts: jsondiff.Options{IgnorePaths: []string{"/id"}},
+			wantDiff: `  {
+    "a": 1,
+    "id": <ignored>
+  }`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "ignore array element",
+			a:    `[1, 2, 3]`,
+			b:    `[1, 5]`,
+			opts: jsondiff.Options{IgnorePaths: []string{"/1", "/2"}},
+			wantDiff: `  [
+    1,
+    <ignored>,
+    <ignored>
+  ]`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "ignore escaped key",
+			a:    `{"a/b": 1, "c~d": 2}`,
+			b:    `{"a/b": 3, "c~d": 4}`,
+			opts: jsondiff.Options{IgnorePaths: []string{"/a~1b", "/c~0d"}},
+			wantDiff: `  {
+    "a/b": <ignored>,
+    "c~d": <ignored>
+  }`,
+			wantKind: jsondiff.Match,
+		},
+
+		// Numbers
+		{
+			name:     "numbers compared by encoding",
+			a:        `1.0`,
+			b:        `1`,
+			wantDiff: `1 => 1.0`,
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name:     "numbers compared numerically",
+			a:        `[1.0, 1e2, 0.30000000000000000001]`,
+			b:        `[1, 100, 0.3]`,
+			opts:     jsondiff.Options{NumericNumbers: true},
+			wantDiff: "  [\n    1.0,\n    1e2,\n~   0.3 => 0.30000000000000000001\n  ]",
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name:     "numbers within tolerance",
+			a:        `[1.05, 2]`,
+			b:        `[1, 2.2]`,
+			opts:     jsondiff.Options{NumberTolerance: 0.1},
+			wantDiff: "  [\n    1.05,\n~   2.2 => 2\n  ]",
+			wantKind: jsondiff.NoMatch,
+		},
+
+		// Null as missing
+		{
+			name: "null as missing",
+			a:    `{"a": 1, "b": null}`,
+			b:    `{"a": 1, "c": null}`,
+			opts: jsondiff.Options{NullAsMissing: true},
+			wantDiff: `  {
+    "a": 1,
+    "b": null,
+    "c": null
+  }`,
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "null not missing",
+			a:    `{"b": null}`,
+			b:    `{}`,
+			wantDiff: `  {
++   "b": null
+  }`,
+			wantKind: jsondiff.NoMatch,
+		},
+
+		// Summary
+		{
+			name:     "summary at root",
+			a:        `true`,
+			b:        `false`,
+			opts:     jsondiff.Options{Summary: true},
+			wantDiff: `false => true`,
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name:     "summary when equal",
+			a:        `[1]`,
+			b:        `[1]`,
+			opts:     jsondiff.Options{Summary: true},
+			wantDiff: "  [\n    1\n  ]",
+			wantKind: jsondiff.Match,
+		},
+		{
+			name: "summary",
+			a:    `{"items": [{"price": 10}, {"price": 5}], "a/b": {"x": 1}, "extra": true}`,
+			b:    `{"items": [{"price": 12}, {"price": 5}, {}], "a/b": [], "gone": null}`,
+			opts: jsondiff.Options{Summary: true},
+			wantDiff: `~ /a~1b: [] => {...}
++ /extra: true
+- /gone: null
+~ /items/0/price: 12 => 10
+- /items/2: {}
+
+  {
+~   "a/b": [] => {...},
++   "extra": true,
+-   "gone": null,
+    "items": [
+      {
+~       "price": 12 => 10
+      },
+      {
+        "price": 5
+      },
+-     {}
+    ]
+  }`,
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name: "summary with max differences",
+			a:    `[1, 2, 3, 4]`,
+			b:    `[0, 0, 0, 4]`,
+			opts: jsondiff.Options{Summary: true, MaxDifferences: 2},
+			wantDiff: `~ /0: 0 => 1
+~ /1: 0 => 2
+... (1 more difference omitted)
+
+  [
+~   0 => 1,
+~   0 => 2,
+~   0 => 3,
+    4
+  ]`,
+			wantKind: jsondiff.NoMatch,
+		},
+
+		// Collapse
+		{
+			name: "collapse unchanged siblings",
+			a:    `{"a": 1, "b": {"c": [1, 2]}, "d": [1, 2, 3, 4], "e": "x", "f": 2, "g": 3}`,
+			b:    `{"a": 1, "b": {"c": [1, 2]}, "d": [1, 2, 0, 4], "e": "y", "f": 2, "g": 3}`,
+			opts: jsondiff.Options{Collapse: true},
+			wantDiff: `  {
+    ... (2 unchanged),
+    "d": [
+      ... (2 unchanged),
+~     0 => 3,
+      ... (1 unchanged)
+    ],
+~   "e": "y" => "x",
+    ... (2 unchanged)
+  }`,
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name: "collapse with missing and extra",
+			a:    `[1, 2, 3]`,
+			b:    `[1, 2]`,
+			opts: jsondiff.Options{Collapse: true},
+			wantDiff: `  [
+    ... (2 unchanged),
++   3
+  ]`,
+			wantKind: jsondiff.NoMatch,
+		},
+		{
+			name: "collapse unordered",
+			a:    `[3, 1, 2]`,
+			b:    `[1, 2, 4]`,
+			opts: jsondiff.Options{Collapse: true, UnorderedArrays: true},
+			wantDiff: `  [
+    ... (2 unchanged),
+-   4,
++   3
+  ]`,
+			wantKind: jsondiff.NoMatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			diff, kind := jsondiff.DiffWith(tt.a, tt.b, tt.opts)
+			g.Should(be.Equal(kind, tt.wantKind))
+			g.Should(be.Equal(diff, tt.wantDiff))
+		})
+	}
+}
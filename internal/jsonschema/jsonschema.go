@@ -0,0 +1,571 @@
+// Package jsonschema validates JSON documents against a subset of JSON Schema
+// draft 2020-12.
+//
+// The supported keywords are type, enum, const, properties, required,
+// additionalProperties, items, pattern, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, minLength, maxLength, minItems, maxItems, and $ref within
+// the same document. Other keywords are ignored.
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Violation is a single way in which a document does not match a schema.
+type Violation struct {
+	// Pointer is the JSON Pointer to the value in the document.
+	Pointer string
+	// Message describes the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "(root)"
+	}
+	return pointer + ": " + v.Message
+}
+
+// Validate checks a decoded document against a decoded schema, returning
+// every violation found. An error is returned if the schema is invalid.
+//
+// Numbers in both the document and the schema must be decoded as
+// [json.Number].
+func Validate(doc, schema any) ([]Violation, error) {
+	v := &validator{
+		root:     schema,
+		patterns: make(map[string]*regexp.Regexp),
+		active:   make(map[refVisit]bool),
+	}
+
+	if err := v.validate(doc, schema, nil); err != nil {
+		return nil, err
+	}
+
+	return v.violations, nil
+}
+
+type validator struct {
+	root       any
+	violations []Violation
+	patterns   map[string]*regexp.Regexp
+
+	// active tracks references currently being applied, to avoid recursing
+	// forever on schemas such as {"$ref": "#"}.
+	active map[refVisit]bool
+}
+
+type refVisit struct {
+	ref     string
+	pointer string
+}
+
+func (v *validator) fail(path []string, format string, args ...any) {
+	v.violations = append(v.violations, Violation{
+		Pointer: pointer(path),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validate(doc, schema any, path []string) error {
+	switch schema := schema.(type) {
+	case bool:
+		if !schema {
+			v.fail(path, "no value is allowed")
+		}
+		return nil
+	case map[string]any:
+		return v.validateObject(doc, schema, path)
+	default:
+		return fmt.Errorf("schema at %s must be an object or boolean", pointerOrRoot(path))
+	}
+}
+
+func (v *validator) validateObject(doc any, schema map[string]any, path []string) error {
+	if ref, ok := schema["$ref"]; ok {
+		if err := v.validateRef(doc, ref, path); err != nil {
+			return err
+		}
+	}
+
+	if err := v.validateType(doc, schema, path); err != nil {
+		return err
+	}
+
+	if err := v.validateEnum(doc, schema, path); err != nil {
+		return err
+	}
+
+	switch doc := doc.(type) {
+	case map[string]any:
+		return v.validateProperties(doc, schema, path)
+	case []any:
+		return v.validateItems(doc, schema, path)
+	case string:
+		return v.validateString(doc, schema, path)
+	case json.Number:
+		return v.validateNumber(doc, schema, path)
+	}
+
+	return nil
+}
+
+func (v *validator) validateEnum(doc any, schema map[string]any, path []string) error {
+	if enum, ok := schema["enum"]; ok {
+		values, ok := enum.([]any)
+		if !ok {
+			return errors.New("enum must be an array")
+		}
+		if !containsValue(values, doc) {
+			v.fail(path, "%s is not one of %s", inline(doc), inline(values))
+		}
+	}
+
+	if c, ok := schema["const"]; ok && !equal(doc, c) {
+		v.fail(path, "%s is not %s", inline(doc), inline(c))
+	}
+
+	return nil
+}
+
+func (v *validator) validateRef(doc, ref any, path []string) error {
+	s, ok := ref.(string)
+	if !ok {
+		return errors.New("$ref must be a string")
+	}
+
+	visit := refVisit{ref: s, pointer: pointer(path)}
+	if v.active[visit] {
+		return nil
+	}
+	v.active[visit] = true
+	defer delete(v.active, visit)
+
+	target, err := v.resolve(s)
+	if err != nil {
+		return err
+	}
+
+	return v.validate(doc, target, path)
+}
+
+// resolve finds the schema for a reference within the root schema.
+func (v *validator) resolve(ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("$ref %q must refer to the same document", ref)
+	}
+
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("$ref %q: %w", ref, err)
+	}
+	if fragment == "" {
+		return v.root, nil
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, fmt.Errorf("$ref %q must be a JSON Pointer", ref)
+	}
+
+	current := v.root
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token = pointerUnescaper.Replace(token)
+
+		switch node := current.(type) {
+		case map[string]any:
+			next, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("$ref %q does not exist", ref)
+			}
+			current = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("$ref %q does not exist", ref)
+			}
+			current = node[i]
+		default:
+			return nil, fmt.Errorf("$ref %q does not exist", ref)
+		}
+	}
+
+	return current, nil
+}
+
+func (v *validator) validateType(doc any, schema map[string]any, path []string) error {
+	t, ok := schema["type"]
+	if !ok {
+		return nil
+	}
+
+	var types []string
+	switch t := t.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, tt := range t {
+			s, ok := tt.(string)
+			if !ok {
+				return errors.New("type must be a string or array of strings")
+			}
+			types = append(types, s)
+		}
+	default:
+		return errors.New("type must be a string or array of strings")
+	}
+
+	for _, want := range types {
+		if hasType(doc, want) {
+			return nil
+		}
+	}
+
+	v.fail(path, "%s is %s, not %s", inline(doc), typeOf(doc), strings.Join(types, " or "))
+	return nil
+}
+
+func (v *validator) validateProperties(doc, schema map[string]any, path []string) error {
+	if err := v.validateRequired(doc, schema, path); err != nil {
+		return err
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	additional, hasAdditional := schema["additionalProperties"]
+
+	for _, k := range sortedKeys(doc) {
+		propPath := appendPath(path, k)
+
+		if sub, ok := properties[k]; ok {
+			if err := v.validate(doc[k], sub, propPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !hasAdditional {
+			continue
+		}
+
+		if allowed, ok := additional.(bool); ok && !allowed {
+			v.fail(propPath, "additional property %q is not allowed", k)
+			continue
+		}
+
+		if err := v.validate(doc[k], additional, propPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validateRequired(doc, schema map[string]any, path []string) error {
+	required, ok := schema["required"]
+	if !ok {
+		return nil
+	}
+
+	names, ok := required.([]any)
+	if !ok {
+		return errors.New("required must be an array")
+	}
+
+	for _, name := range names {
+		name, ok := name.(string)
+		if !ok {
+			return errors.New("required must be an array of strings")
+		}
+		if _, ok := doc[name]; !ok {
+			v.fail(path, "missing required property %q", name)
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validateItems(doc []any, schema map[string]any, path []string) error {
+	if err := v.validateCount(len(doc), "items", schema, path); err != nil {
+		return err
+	}
+
+	items, ok := schema["items"]
+	if !ok {
+		return nil
+	}
+
+	for i, item := range doc {
+		if err := v.validate(item, items, appendPath(path, strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validateString(doc string, schema map[string]any, path []string) error {
+	if err := v.validateCount(utf8.RuneCountInString(doc), "length", schema, path); err != nil {
+		return err
+	}
+
+	p, ok := schema["pattern"]
+	if !ok {
+		return nil
+	}
+
+	expr, ok := p.(string)
+	if !ok {
+		return errors.New("pattern must be a string")
+	}
+
+	re, ok := v.patterns[expr]
+	if !ok {
+		var err error
+		re, err = regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("pattern %q: %w", expr, err)
+		}
+		v.patterns[expr] = re
+	}
+
+	if !re.MatchString(doc) {
+		v.fail(path, "%s does not match pattern %q", inline(doc), expr)
+	}
+
+	return nil
+}
+
+// validateCount checks the minimum and maximum keywords for the length of a
+// string or array, such as minLength or maxItems.
+func (v *validator) validateCount(n int, noun string, schema map[string]any, path []string) error {
+	suffix := strings.ToUpper(noun[:1]) + noun[1:]
+
+	if minimum, ok, err := intKeyword(schema, "min"+suffix); err != nil {
+		return err
+	} else if ok && n < minimum {
+		v.fail(path, "%s %d is less than the minimum of %d", noun, n, minimum)
+	}
+
+	if maximum, ok, err := intKeyword(schema, "max"+suffix); err != nil {
+		return err
+	} else if ok && n > maximum {
+		v.fail(path, "%s %d is greater than the maximum of %d", noun, n, maximum)
+	}
+
+	return nil
+}
+
+func (v *validator) validateNumber(doc json.Number, schema map[string]any, path []string) error {
+	n, ok := new(big.Rat).SetString(string(doc))
+	if !ok {
+		return nil
+	}
+
+	checks := []struct {
+		keyword string
+		fails   func(cmp int) bool
+		message string
+	}{
+		{"minimum", func(c int) bool { return c < 0 }, "less than the minimum of"},
+		{"maximum", func(c int) bool { return c > 0 }, "greater than the maximum of"},
+		{"exclusiveMinimum", func(c int) bool { return c <= 0 }, "not greater than"},
+		{"exclusiveMaximum", func(c int) bool { return c >= 0 }, "not less than"},
+	}
+
+	for _, check := range checks {
+		limit, ok := schema[check.keyword]
+		if !ok {
+			continue
+		}
+
+		l, ok := limit.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s must be a number", check.keyword)
+		}
+
+		r, ok := new(big.Rat).SetString(string(l))
+		if !ok {
+			return fmt.Errorf("%s must be a number", check.keyword)
+		}
+
+		if check.fails(n.Cmp(r)) {
+			v.fail(path, "%s is %s %s", doc, check.message, l)
+		}
+	}
+
+	return nil
+}
+
+func intKeyword(schema map[string]any, keyword string) (int, bool, error) {
+	value, ok := schema[keyword]
+	if !ok {
+		return 0, false, nil
+	}
+
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0, false, fmt.Errorf("%s must be a non-negative integer", keyword)
+	}
+
+	i, err := strconv.Atoi(string(n))
+	if err != nil || i < 0 {
+		return 0, false, fmt.Errorf("%s must be a non-negative integer", keyword)
+	}
+
+	return i, true, nil
+}
+
+func hasType(doc any, t string) bool {
+	switch t {
+	case "integer":
+		n, ok := doc.(json.Number)
+		if !ok {
+			return false
+		}
+		r, ok := new(big.Rat).SetString(string(n))
+		return ok && r.IsInt()
+	case "number":
+		_, ok := doc.(json.Number)
+		return ok
+	default:
+		return typeOf(doc) == t
+	}
+}
+
+func typeOf(doc any) string {
+	switch doc.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", doc)
+	}
+}
+
+func containsValue(values []any, doc any) bool {
+	for _, value := range values {
+		if equal(doc, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal compares two decoded values, comparing numbers by value.
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		ar, aOk := new(big.Rat).SetString(string(a))
+		br, bOk := new(big.Rat).SetString(string(b))
+		if !aOk || !bOk {
+			return a == b
+		}
+		return ar.Cmp(br) == 0
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, av := range a {
+			bv, ok := b[k]
+			if !ok || !equal(av, bv) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// inline formats a decoded value on a single line.
+func inline(v any) string {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			return "{}"
+		}
+		return "{...}"
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, vv := range v {
+			parts = append(parts, inline(vv))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case string:
+		return strconv.Quote(v)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func appendPath(path []string, token string) []string {
+	out := make([]string, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, token)
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// pointer returns the JSON Pointer for a path, as in RFC 6901.
+func pointer(path []string) string {
+	var sb strings.Builder
+	for _, token := range path {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(token))
+	}
+	return sb.String()
+}
+
+func pointerOrRoot(path []string) string {
+	if len(path) == 0 {
+		return "(root)"
+	}
+	return pointer(path)
+}
@@ -0,0 +1,180 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/jsondiff"
+	"github.com/rliebz/ghost/internal/jsonschema"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		schema string
+		want   []string
+	}{
+		{
+			name:   "true schema",
+			doc:    `{"a": 1}`,
+			schema: `true`,
+		},
+		{
+			name:   "false schema",
+			doc:    `1`,
+			schema: `false`,
+			want:   []string{"(root): no value is allowed"},
+		},
+		{
+			name:   "type",
+			doc:    `"foo"`,
+			schema: `{"type": "number"}`,
+			want:   []string{`(root): "foo" is string, not number`},
+		},
+		{
+			name:   "type list",
+			doc:    `null`,
+			schema: `{"type": ["string", "null"]}`,
+		},
+		{
+			name:   "integer",
+			doc:    `[1, 1.0, 1.5]`,
+			schema: `{"items": {"type": "integer"}}`,
+			want:   []string{"/2: 1.5 is number, not integer"},
+		},
+		{
+			name: "object",
+			doc:  `{"id": "x", "extra": true, "a/b": 1}`,
+			schema: `{
+				"type": "object",
+				"properties": {"id": {"type": "integer"}, "a/b": {}},
+				"required": ["id", "name"],
+				"additionalProperties": false
+			}`,
+			want: []string{
+				`(root): missing required property "name"`,
+				`/extra: additional property "extra" is not allowed`,
+				`/id: "x" is string, not integer`,
+			},
+		},
+		{
+			name:   "additional properties schema",
+			doc:    `{"a": 1, "b": "x"}`,
+			schema: `{"additionalProperties": {"type": "number"}}`,
+			want:   []string{`/b: "x" is string, not number`},
+		},
+		{
+			name:   "enum and const",
+			doc:    `{"a": "c", "b": 2.0}`,
+			schema: `{"properties": {"a": {"enum": ["a", "b"]}, "b": {"const": 2}}}`,
+			want:   []string{`/a: "c" is not one of ["a", "b"]`},
+		},
+		{
+			name:   "string",
+			doc:    `["héllo", "ab", "abcdefg"]`,
+			schema: `{"items": {"minLength": 3, "maxLength": 5, "pattern": "^[a-z]+$"}}`,
+			want: []string{
+				`/0: "héllo" does not match pattern "^[a-z]+$"`,
+				"/1: length 2 is less than the minimum of 3",
+				"/2: length 7 is greater than the maximum of 5",
+			},
+		},
+		{
+			name: "numbers",
+			doc:  `[0, 5, 10]`,
+			schema: `{
+				"minItems": 4,
+				"items": {"minimum": 1, "maximum": 9, "exclusiveMaximum": 5}
+			}`,
+			want: []string{
+				"(root): items 3 is less than the minimum of 4",
+				"/0: 0 is less than the minimum of 1",
+				"/1: 5 is not less than 5",
+				"/2: 10 is greater than the maximum of 9",
+				"/2: 10 is not less than 5",
+			},
+		},
+		{
+			name: "refs",
+			doc:  `{"name": "a", "children": [{"name": 1}]}`,
+			schema: `{
+				"$defs": {"name": {"type": "string"}},
+				"properties": {
+					"name": {"$ref": "#/$defs/name"},
+					"children": {"items": {"$ref": "#"}}
+				}
+			}`,
+			want: []string{`/children/0/name: 1 is number, not string`},
+		},
+		{
+			name:   "recursive ref",
+			doc:    `1`,
+			schema: `{"$ref": "#"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			violations, err := jsonschema.Validate(decode(t, tt.doc), decode(t, tt.schema))
+			g.NoError(err)
+
+			var got []string
+			for _, v := range violations {
+				got = append(got, v.String())
+			}
+			g.Should(be.DeepEqual(got, tt.want))
+		})
+	}
+}
+
+func TestValidateInvalidSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "not an object",
+			schema: `1`,
+			want:   "schema at (root) must be an object or boolean",
+		},
+		{
+			name:   "bad pattern",
+			schema: `{"pattern": "("}`,
+			want:   "pattern \"(\": error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "missing ref",
+			schema: `{"$ref": "#/$defs/nope"}`,
+			want:   `$ref "#/$defs/nope" does not exist`,
+		},
+		{
+			name:   "remote ref",
+			schema: `{"$ref": "https://example.com/schema.json"}`,
+			want:   `$ref "https://example.com/schema.json" must refer to the same document`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			_, err := jsonschema.Validate(decode(t, `"foo"`), decode(t, tt.schema))
+			g.Should(be.ErrorEqual(err, tt.want))
+		})
+	}
+}
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+
+	v, err := jsondiff.Decode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
@@ -0,0 +1,59 @@
+// Package limits controls how much output a failure may produce, so that
+// large values do not flood test logs.
+//
+// Each limit can be changed with an environment variable. A value of 0 removes
+// the limit.
+package limits
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// These are the limits used if no environment variable is set.
+const (
+	DefaultLines        = 500
+	DefaultElements     = 100
+	DefaultStringLength = 1000
+)
+
+// Lines returns the most lines of a diff to show, which can be set with
+// GHOST_MAX_DIFF_LINES.
+func Lines() int {
+	return fromEnv("GHOST_MAX_DIFF_LINES", DefaultLines)
+}
+
+// Elements returns the most elements or differences to show for a value,
+// which can be set with GHOST_MAX_ELEMENTS.
+func Elements() int {
+	return fromEnv("GHOST_MAX_ELEMENTS", DefaultElements)
+}
+
+// StringLength returns the most characters of a string to show, which can be
+// set with GHOST_MAX_STRING_LENGTH.
+func StringLength() int {
+	return fromEnv("GHOST_MAX_STRING_LENGTH", DefaultStringLength)
+}
+
+// Exceeded reports whether n is over a limit.
+func Exceeded(n, limit int) bool {
+	return limit > 0 && n > limit
+}
+
+// Omitted describes how many of something were left out.
+func Omitted(n int, singular, plural string) string {
+	noun := plural
+	if n == 1 {
+		noun = singular
+	}
+	return fmt.Sprintf("... (%d more %s omitted)", n, noun)
+}
+
+func fromEnv(name string, fallback int) int {
+	n, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || n < 0 {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,50 @@
+package limits_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/limits"
+)
+
+func TestLimits(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_DIFF_LINES", "")
+		t.Setenv("GHOST_MAX_ELEMENTS", "invalid")
+		t.Setenv("GHOST_MAX_STRING_LENGTH", "-1")
+
+		g.Should(be.Equal(limits.Lines(), limits.DefaultLines))
+		g.Should(be.Equal(limits.Elements(), limits.DefaultElements))
+		g.Should(be.Equal(limits.StringLength(), limits.DefaultStringLength))
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_MAX_DIFF_LINES", "1")
+		t.Setenv("GHOST_MAX_ELEMENTS", "2")
+		t.Setenv("GHOST_MAX_STRING_LENGTH", "0")
+
+		g.Should(be.Equal(limits.Lines(), 1))
+		g.Should(be.Equal(limits.Elements(), 2))
+		g.Should(be.Equal(limits.StringLength(), 0))
+	})
+}
+
+func TestExceeded(t *testing.T) {
+	g := ghost.New(t)
+
+	g.Should(be.False(limits.Exceeded(3, 3)))
+	g.Should(be.True(limits.Exceeded(4, 3)))
+	g.Should(be.False(limits.Exceeded(1000, 0)))
+}
+
+func TestOmitted(t *testing.T) {
+	g := ghost.New(t)
+
+	g.Should(be.Equal(limits.Omitted(1, "line", "lines"), "... (1 more line omitted)"))
+	g.Should(be.Equal(limits.Omitted(2, "line", "lines"), "... (2 more lines omitted)"))
+}
@@ -0,0 +1,481 @@
+// Package powerassert renders the values of sub-expressions in a boolean
+// expression as a diagram, in the style of power-assert.
+package powerassert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Render describes an expression whose sub-expressions were wrapped in calls
+// to a capture function with the given name, using the values that were
+// captured in the order they were evaluated.
+//
+// It returns the expression with the capture calls removed, along with a
+// diagram of every known value. If no values could be described, ok is false.
+func Render(expr string, result bool, captureFunc string, captured []any) (string, string, bool) {
+	if len(captured) == 0 {
+		return "", "", false
+	}
+
+	root, err := parser.ParseExpr(expr)
+	if err != nil {
+		return "", "", false
+	}
+
+	e := &evaluator{
+		captureFunc: captureFunc,
+		captured:    captured,
+		values:      make(map[ast.Node]value),
+	}
+
+	root = e.strip(root)
+	e.assign(root)
+
+	// Values left over mean the captured values did not all come from this
+	// expression, so none of them can be trusted.
+	if len(e.values) == 0 || e.next != len(e.captured) {
+		return "", "", false
+	}
+
+	// The result of the whole expression is always known.
+	e.values[root] = value{v: result, known: true}
+
+	text, positions, err := layout(root)
+	if err != nil {
+		return "", "", false
+	}
+
+	var entries []entry
+	for node, val := range e.values {
+		entries = append(entries, entry{
+			col:  positions[node],
+			expr: nodeString(node),
+			text: formatValue(val.v),
+		})
+	}
+
+	if strings.Contains(text, "\n") {
+		return text, list(entries), true
+	}
+
+	return text, diagram(entries), true
+}
+
+// value is the value of an expression, if known.
+type value struct {
+	v     any
+	known bool
+}
+
+type evaluator struct {
+	captureFunc string
+	captured    []any
+	next        int
+
+	// captures are the expressions which were wrapped in capture calls.
+	captures map[ast.Expr]bool
+	values   map[ast.Node]value
+}
+
+// strip removes capture calls from an expression, remembering which
+// expressions were captured.
+func (e *evaluator) strip(expr ast.Expr) ast.Expr {
+	e.captures = make(map[ast.Expr]bool)
+
+	var walk func(ast.Expr) ast.Expr
+	walk = func(expr ast.Expr) ast.Expr {
+		switch n := expr.(type) {
+		case *ast.CallExpr:
+			// Capture calls take the captures to record to, then the value.
+			if e.isCapture(n) && len(n.Args) == 2 {
+				inner := walk(n.Args[1])
+				e.captures[inner] = true
+				return inner
+			}
+			n.Fun = walk(n.Fun)
+			for i, arg := range n.Args {
+				n.Args[i] = walk(arg)
+			}
+		case *ast.BinaryExpr:
+			n.X = walk(n.X)
+			n.Y = walk(n.Y)
+		case *ast.UnaryExpr:
+			n.X = walk(n.X)
+		case *ast.ParenExpr:
+			n.X = walk(n.X)
+		case *ast.SelectorExpr:
+			n.X = walk(n.X)
+		case *ast.IndexExpr:
+			n.X = walk(n.X)
+			n.Index = walk(n.Index)
+		case *ast.StarExpr:
+			n.X = walk(n.X)
+		}
+		return expr
+	}
+
+	return walk(expr)
+}
+
+func (e *evaluator) isCapture(call *ast.CallExpr) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun.Name == e.captureFunc
+	case *ast.SelectorExpr:
+		return fun.Sel.Name == e.captureFunc
+	case *ast.IndexExpr: // explicit type arguments
+		return e.isCapture(&ast.CallExpr{Fun: fun.X})
+	}
+	return false
+}
+
+// assign matches captured values to expressions and computes the values of
+// any operations whose operands are known.
+func (e *evaluator) assign(root ast.Expr) {
+	// If every capture was evaluated, there's no need to model short-circuit
+	// evaluation to match values to expressions.
+	skipShortCircuit := len(e.captures) == len(e.captured)
+	e.eval(root, skipShortCircuit)
+}
+
+func (e *evaluator) eval(expr ast.Expr, ignoreShortCircuit bool) value {
+	var out value
+
+	switch n := expr.(type) {
+	case *ast.BasicLit:
+		if v := constant.MakeFromLiteral(n.Value, n.Kind, 0); v.Kind() != constant.Unknown {
+			out = value{v: fromConstant(v), known: true}
+		}
+		return e.captureValue(expr, out)
+	case *ast.Ident:
+		switch n.Name {
+		case "true", "false":
+			return value{v: n.Name == "true", known: true}
+		}
+	case *ast.ParenExpr:
+		out = e.eval(n.X, ignoreShortCircuit)
+		return e.captureValue(expr, out)
+	case *ast.UnaryExpr:
+		x := e.eval(n.X, ignoreShortCircuit)
+		out = unaryOp(n.Op, x)
+	case *ast.BinaryExpr:
+		x := e.eval(n.X, ignoreShortCircuit)
+
+		if !ignoreShortCircuit && x.known && isShortCircuit(n.Op, x.v) {
+			out = x
+			break
+		}
+
+		y := e.eval(n.Y, ignoreShortCircuit)
+		out = binaryOp(n.Op, x, y)
+	default:
+		ast.Inspect(expr, func(node ast.Node) bool {
+			if node == expr {
+				return true
+			}
+			if sub, ok := node.(ast.Expr); ok {
+				e.eval(sub, ignoreShortCircuit)
+				return false
+			}
+			return true
+		})
+	}
+
+	out = e.captureValue(expr, out)
+	if out.known {
+		e.values[expr] = out
+	}
+	return out
+}
+
+// captureValue returns the captured value for an expression if it was
+// captured, or the computed value otherwise.
+func (e *evaluator) captureValue(expr ast.Expr, computed value) value {
+	if !e.captures[expr] || e.next >= len(e.captured) {
+		return computed
+	}
+
+	out := value{v: e.captured[e.next], known: true}
+	e.next++
+	e.values[expr] = out
+	return out
+}
+
+func isShortCircuit(op token.Token, x any) bool {
+	b, ok := x.(bool)
+	if !ok {
+		return false
+	}
+	return (op == token.LAND && !b) || (op == token.LOR && b)
+}
+
+func unaryOp(op token.Token, x value) value {
+	if !x.known {
+		return value{}
+	}
+
+	cx, ok := toConstant(x.v)
+	if !ok {
+		return value{}
+	}
+
+	switch op {
+	case token.NOT:
+		if cx.Kind() != constant.Bool {
+			return value{}
+		}
+	case token.SUB, token.ADD:
+		if cx.Kind() != constant.Int && cx.Kind() != constant.Float {
+			return value{}
+		}
+	default:
+		return value{}
+	}
+
+	return value{v: fromConstant(constant.UnaryOp(op, cx, 0)), known: true}
+}
+
+func binaryOp(op token.Token, x, y value) value {
+	if !x.known || !y.known {
+		return value{}
+	}
+
+	cx, ok := toConstant(x.v)
+	if !ok {
+		return value{}
+	}
+
+	cy, ok := toConstant(y.v)
+	if !ok {
+		return value{}
+	}
+
+	if !compatible(cx, cy) {
+		return value{}
+	}
+
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return value{v: constant.Compare(cx, op, cy), known: true}
+	case token.LAND, token.LOR:
+		if cx.Kind() != constant.Bool {
+			return value{}
+		}
+		return value{v: fromConstant(constant.BinaryOp(cx, op, cy)), known: true}
+	case token.ADD, token.SUB, token.MUL:
+		if cx.Kind() == constant.Bool || (op != token.ADD && cx.Kind() == constant.String) {
+			return value{}
+		}
+		return value{v: fromConstant(constant.BinaryOp(cx, op, cy)), known: true}
+	}
+
+	return value{}
+}
+
+// compatible returns whether two constants can be used in the same operation.
+func compatible(x, y constant.Value) bool {
+	numeric := func(v constant.Value) bool {
+		return v.Kind() == constant.Int || v.Kind() == constant.Float
+	}
+	return x.Kind() == y.Kind() || (numeric(x) && numeric(y))
+}
+
+func toConstant(v any) (constant.Value, bool) {
+	switch v := v.(type) {
+	case bool:
+		return constant.MakeBool(v), true
+	case string:
+		return constant.MakeString(v), true
+	case int:
+		return constant.MakeInt64(int64(v)), true
+	case int8:
+		return constant.MakeInt64(int64(v)), true
+	case int16:
+		return constant.MakeInt64(int64(v)), true
+	case int32:
+		return constant.MakeInt64(int64(v)), true
+	case int64:
+		return constant.MakeInt64(v), true
+	case uint:
+		return constant.MakeUint64(uint64(v)), true
+	case uint8:
+		return constant.MakeUint64(uint64(v)), true
+	case uint16:
+		return constant.MakeUint64(uint64(v)), true
+	case uint32:
+		return constant.MakeUint64(uint64(v)), true
+	case uint64:
+		return constant.MakeUint64(v), true
+	case float32:
+		return constant.MakeFloat64(float64(v)), true
+	case float64:
+		return constant.MakeFloat64(v), true
+	}
+	return nil, false
+}
+
+func fromConstant(v constant.Value) any {
+	switch v.Kind() {
+	case constant.Bool:
+		return constant.BoolVal(v)
+	case constant.String:
+		return constant.StringVal(v)
+	case constant.Int:
+		if i, ok := constant.Int64Val(v); ok {
+			return int(i)
+		}
+		return v.ExactString()
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return f
+	}
+	return v.ExactString()
+}
+
+// layout prints an expression, returning the printed text along with the
+// column of each node within it, counted in runes from the start of the text.
+func layout(root ast.Expr) (string, map[ast.Node]int, error) {
+	text := nodeString(root)
+
+	// Re-parse the printed text so that positions match the output. The tree
+	// has the same shape as the original, so nodes can be paired in order.
+	fset := token.NewFileSet()
+	printed, err := parser.ParseExprFrom(fset, "", text, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var original, reparsed []ast.Node
+	ast.Inspect(root, func(n ast.Node) bool {
+		if n != nil {
+			original = append(original, n)
+		}
+		return true
+	})
+	ast.Inspect(printed, func(n ast.Node) bool {
+		if n != nil {
+			reparsed = append(reparsed, n)
+		}
+		return true
+	})
+
+	if len(original) != len(reparsed) {
+		return "", nil, errors.New("expression changed shape when printed")
+	}
+
+	positions := make(map[ast.Node]int, len(original))
+	for i, n := range original {
+		offset := fset.Position(anchor(reparsed[i])).Offset
+		positions[n] = utf8.RuneCountInString(text[:offset])
+	}
+
+	return text, positions, nil
+}
+
+// anchor returns the position within an expression that its value should be
+// drawn under.
+func anchor(n ast.Node) token.Pos {
+	switch n := n.(type) {
+	case *ast.BinaryExpr:
+		return n.OpPos
+	case *ast.SelectorExpr:
+		return n.Sel.Pos()
+	case *ast.IndexExpr:
+		return n.Lbrack
+	}
+	return n.Pos()
+}
+
+type entry struct {
+	col  int
+	expr string
+	text string
+}
+
+// diagram draws values beneath the columns of a single-line expression.
+func diagram(entries []entry) string {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].col > entries[j].col
+	})
+
+	// Values in the same column can't be drawn separately, so keep the first.
+	unique := make([]entry, 0, len(entries))
+	for i, e := range entries {
+		if i > 0 && e.col == entries[i-1].col {
+			continue
+		}
+		unique = append(unique, e)
+	}
+	entries = unique
+
+	lines := make([]string, 0, len(entries)+1)
+	lines = append(lines, bars(entries))
+	for i, e := range entries {
+		line := []rune(bars(entries[i+1:]))
+		for len(line) < e.col {
+			line = append(line, ' ')
+		}
+		line = append(line[:e.col], []rune(e.text)...)
+		lines = append(lines, string(line))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// bars draws a vertical bar at the column of every entry.
+func bars(entries []entry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, e := range entries {
+		if e.col+1 > width {
+			width = e.col + 1
+		}
+	}
+
+	line := []rune(strings.Repeat(" ", width))
+	for _, e := range entries {
+		line[e.col] = '|'
+	}
+	return strings.TrimRight(string(line), " ")
+}
+
+// list describes values one per line, for expressions spanning multiple lines.
+func list(entries []entry) string {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].col < entries[j].col
+	})
+
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		lines = append(lines, fmt.Sprintf("%s = %s", e.expr, e.text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func nodeString(node ast.Node) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), node); err != nil {
+		return fmt.Sprintf("%T", node)
+	}
+	return buf.String()
+}
+
+func formatValue(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
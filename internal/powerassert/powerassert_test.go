@@ -0,0 +1,143 @@
+package powerassert_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/powerassert"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		result   bool
+		captured []any
+		wantExpr string
+		wantDiag string
+		wantOk   bool
+	}{
+		{
+			name:     "short circuit and",
+			expr:     "Capture(&c, a) > 3 && Capture(&c, b)",
+			result:   false,
+			captured: []any{2},
+			wantExpr: "a > 3 && b",
+			wantDiag: `| |   |
+| |   false
+| false
+2`,
+			wantOk: true,
+		},
+		{
+			name:     "short circuit or",
+			expr:     "Capture(&c, a) > 3 || Capture(&c, b)",
+			result:   true,
+			captured: []any{5},
+			wantExpr: "a > 3 || b",
+			wantDiag: `| |   |
+| |   true
+| true
+5`,
+			wantOk: true,
+		},
+		{
+			name:     "parentheses",
+			expr:     "(Capture(&c, a) + Capture(&c, b)) * 2 == 10",
+			result:   false,
+			captured: []any{1, 2},
+			wantExpr: "(a+b)*2 == 10",
+			wantDiag: ` ||| |  |
+ ||| |  false
+ ||| 6
+ ||2
+ |3
+ 1`,
+			wantOk: true,
+		},
+		{
+			name:     "not",
+			expr:     "!Capture(&c, ok)",
+			result:   false,
+			captured: []any{true},
+			wantExpr: "!ok",
+			wantDiag: `||
+|true
+false`,
+			wantOk: true,
+		},
+		{
+			name:     "negation",
+			expr:     "-Capture(&c, n) < 0",
+			result:   true,
+			captured: []any{3},
+			wantExpr: "-n < 0",
+			wantDiag: `|| |
+|| true
+|3
+-3`,
+			wantOk: true,
+		},
+		{
+			name:     "mixed int and float",
+			expr:     "Capture(&c, i) < Capture(&c, f)",
+			result:   true,
+			captured: []any{1, 1.5},
+			wantExpr: "i < f",
+			wantDiag: `| | |
+| | 1.5
+| true
+1`,
+			wantOk: true,
+		},
+		{
+			name:     "multiline",
+			expr:     "Capture(&c, a) > 1 &&\n\tCapture(&c, b)",
+			result:   false,
+			captured: []any{2, false},
+			wantExpr: "a > 1 && b",
+			wantDiag: `| |   |  |
+| |   |  false
+| |   false
+| true
+2`,
+			wantOk: true,
+		},
+		{
+			name:     "too many captured values",
+			expr:     "Capture(&c, a) > 2",
+			result:   false,
+			captured: []any{5, 1},
+		},
+		{
+			name:     "no captured values",
+			expr:     "Capture(&c, a) > 2",
+			result:   false,
+			captured: nil,
+		},
+		{
+			name:     "no capture calls",
+			expr:     "a > 2",
+			result:   false,
+			captured: []any{1},
+		},
+		{
+			name:     "invalid expression",
+			expr:     "Capture(&c, a) >",
+			result:   false,
+			captured: []any{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			expr, diag, ok := powerassert.Render(tt.expr, tt.result, "Capture", tt.captured)
+			g.Should(be.Equal(ok, tt.wantOk))
+			g.Should(be.Equal(expr, tt.wantExpr))
+			g.Should(be.Equal(diag, tt.wantDiag))
+		})
+	}
+}
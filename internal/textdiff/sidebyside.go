@@ -0,0 +1,206 @@
+package textdiff
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rliebz/ghost/internal/color"
+)
+
+// diffHeader starts each diff in a message.
+const diffHeader = "diff (-want +got):"
+
+// columnSeparator divides the two columns of a side-by-side diff.
+const columnSeparator = " | "
+
+// SideBySide rewrites each diff in a message so that the lines of want and got
+// are shown in two columns, fitting the message within width columns.
+//
+// A diff starts with a "diff (-want +got):" line, and continues for as long
+// as lines start with a diff prefix. Removed lines are paired with the added
+// lines that follow them, and unchanged lines are shown in both columns.
+// Lines that are neither, such as hunk headers, span both columns. Cells that
+// do not fit are truncated with an ellipsis.
+//
+// A diff may be indented, such as within the message of a batch of failures,
+// as long as every line of it shares the indentation of its header.
+//
+// The columns are only colored if the message is.
+func SideBySide(message string, width int) string {
+	colored := color.Strip(message) != message
+	lines := strings.Split(message, "\n")
+
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		out = append(out, lines[i])
+
+		header := color.Strip(lines[i])
+		indent := header[:len(header)-len(strings.TrimLeft(header, " \t"))]
+		if header[len(indent):] != diffHeader {
+			continue
+		}
+
+		var diff []string
+		for i+1 < len(lines) {
+			line, ok := diffLine(color.Strip(lines[i+1]), indent)
+			if !ok {
+				break
+			}
+			diff = append(diff, line)
+			i++
+		}
+
+		for _, row := range renderColumns(diff, width-utf8.RuneCountInString(expandTabs(indent))) {
+			if row != "" {
+				row = indent + row
+			}
+			out = append(out, row)
+		}
+	}
+
+	if !colored {
+		return color.Strip(strings.Join(out, "\n"))
+	}
+	return strings.Join(out, "\n")
+}
+
+// diffLine returns a line of a diff with its indentation removed, or false if
+// the line is not part of a diff.
+func diffLine(line, indent string) (string, bool) {
+	if line == "" {
+		return line, true
+	}
+
+	if !strings.HasPrefix(line, indent) {
+		return "", false
+	}
+
+	// go-cmp uses non-breaking spaces in place of some spaces to keep its
+	// output from being relied upon, including the prefix of unchanged lines.
+	line = strings.ReplaceAll(line[len(indent):], "\u00a0", " ")
+	return line, isDiffLine(line)
+}
+
+func isDiffLine(line string) bool {
+	if line == "" {
+		return true
+	}
+
+	switch line[0] {
+	case ' ', '-', '+', '~', '@', '\\':
+		return true
+	default:
+		return false
+	}
+}
+
+// renderColumns lays out the lines of a diff in two columns.
+func renderColumns(lines []string, width int) []string {
+	cellWidth := (width - utf8.RuneCountInString(columnSeparator)) / 2
+	if cellWidth < 2 {
+		cellWidth = 2
+	}
+
+	var rows []string
+	for i := 0; i < len(lines); {
+		line := expandTabs(lines[i])
+
+		switch {
+		case line == "":
+			rows = append(rows, "")
+			i++
+		case line[0] == ' ':
+			rows = append(rows, row(line, line, cellWidth))
+			i++
+		case line[0] == '-' || line[0] == '+':
+			var pairs []string
+			pairs, i = pairChanges(lines, i, cellWidth)
+			rows = append(rows, pairs...)
+		case line[0] == '~':
+			rows = append(rows, color.Yellow("~")+truncate(line[1:], width-1))
+			i++
+		default:
+			rows = append(rows, color.Yellow(truncate(line, width)))
+			i++
+		}
+	}
+
+	return rows
+}
+
+// pairChanges lays out a run of removed lines followed by a run of added lines
+// starting at i, returning the rows and the index after the runs.
+func pairChanges(lines []string, i, cellWidth int) ([]string, int) {
+	var removed, added []string
+	for i < len(lines) && strings.HasPrefix(lines[i], "-") {
+		removed = append(removed, expandTabs(lines[i]))
+		i++
+	}
+	for i < len(lines) && strings.HasPrefix(lines[i], "+") {
+		added = append(added, expandTabs(lines[i]))
+		i++
+	}
+
+	n := len(removed)
+	if len(added) > n {
+		n = len(added)
+	}
+
+	rows := make([]string, 0, n)
+	for j := 0; j < n; j++ {
+		var left, right string
+		if j < len(removed) {
+			left = removed[j]
+		}
+		if j < len(added) {
+			right = added[j]
+		}
+		rows = append(rows, row(left, right, cellWidth))
+	}
+
+	return rows, i
+}
+
+func row(left, right string, cellWidth int) string {
+	l := pad(truncate(left, cellWidth), cellWidth)
+	r := truncate(right, cellWidth)
+
+	if strings.HasPrefix(left, "-") {
+		l = color.Red(l)
+	}
+	if strings.HasPrefix(right, "+") {
+		r = color.Green(r)
+	}
+
+	return strings.TrimRight(l+columnSeparator+r, " ")
+}
+
+// truncate shortens s to at most width runes, ending it with an ellipsis if
+// anything was removed.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width < 1 {
+		return ""
+	}
+
+	rs := []rune(s)
+	return string(rs[:width-1]) + "…"
+}
+
+func pad(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+// tabExpander replaces tabs with spaces so that the width of a line is known,
+// and replaces the non-breaking spaces go-cmp uses to stabilize its output.
+var tabExpander = strings.NewReplacer("\t", "    ", "\u00a0", " ")
+
+func expandTabs(s string) string {
+	return tabExpander.Replace(s)
+}
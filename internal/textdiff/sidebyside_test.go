@@ -0,0 +1,131 @@
+package textdiff_test
+
+import (
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/textdiff"
+)
+
+func TestSideBySide(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		width   int
+		want    string
+	}{
+		{
+			name:    "no diff",
+			message: "got != want\ngot:  1\nwant: 2\n",
+			width:   42,
+			want:    "got != want\ngot:  1\nwant: 2\n",
+		},
+		{
+			name: "unified",
+			message: `got != want
+diff (-want +got):
+@@ -1,4 +1,4 @@
+ host: example.com
+-port: 80
++port: 8080
+-tls: false
+ name: web
++debug: true
+`,
+			width: 42,
+			want: `got != want
+diff (-want +got):
+@@ -1,4 +1,4 @@
+ host: example.com  |  host: example.com
+-port: 80           | +port: 8080
+-tls: false         |
+ name: web          |  name: web
+                    | +debug: true
+`,
+		},
+		{
+			name: "truncated",
+			message: `diff (-want +got):
+ a line that is too long to fit
+-short
++a replacement that is also too long`,
+			width: 32,
+			want: `diff (-want +got):
+ a line that … |  a line that …
+-short         | +a replacemen…`,
+		},
+		{
+			name:    "go-cmp",
+			message: be.DeepEqual([]int{1, 2, 3}, []int{1, 5, 3}).Message,
+			width:   42,
+			want: `[]int{1, 2, 3} != []int{1, 5, 3}
+diff (-want +got):
+  []int{            |   []int{
+      1,            |       1,
+-     5,            | +     2,
+      3,            |       3,
+  }                 |   }
+`,
+		},
+		{
+			name: "non-breaking spaces",
+			message: "diff (-want +got):\n" +
+				"\u00a0\u00a0[]int{\n" +
+				"-\u00a0\t5,\n" +
+				"+\u00a0\t2,\n" +
+				"\u00a0\u00a0}\n",
+			width: 42,
+			want: `diff (-want +got):
+  []int{            |   []int{
+-     5,            | +     2,
+  }                 |   }
+`,
+		},
+		{
+			name: "indented",
+			message: "1 of 1 assertions failed\n\n" +
+				"1) file_test.go:12\n" +
+				"\tgot != want\n" +
+				"\tdiff (-want +got):\n" +
+				"\t-port: 80\n" +
+				"\t+port: 8080\n" +
+				"\n" +
+				"2) file_test.go:13",
+			width: 46,
+			want: "1 of 1 assertions failed\n\n" +
+				"1) file_test.go:12\n" +
+				"\tgot != want\n" +
+				"\tdiff (-want +got):\n" +
+				"\t-port: 80           | +port: 8080\n" +
+				"\n" +
+				"2) file_test.go:13",
+		},
+		{
+			name: "json",
+			message: `diff (-want +got):
+~ /a: 1 => 2
+
+  {
+~   "a": 1 => 2
+  }
+after`,
+			width: 42,
+			want: `diff (-want +got):
+~ /a: 1 => 2
+
+  {                 |   {
+~   "a": 1 => 2
+  }                 |   }
+after`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			g.Should(be.Equal(textdiff.SideBySide(tt.message, tt.width), tt.want))
+		})
+	}
+}
@@ -0,0 +1,276 @@
+// Package textdiff computes the differences between two sequences.
+package textdiff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An Op is a single step in an edit script.
+type Op int
+
+// These are the steps of an edit script.
+const (
+	// Equal keeps an element present in both sequences.
+	Equal Op = iota
+	// Delete removes an element of the first sequence.
+	Delete
+	// Insert adds an element of the second sequence.
+	Insert
+)
+
+// maxEdits bounds the work done to find a minimal edit script. Beyond it, the
+// remaining elements are replaced wholesale.
+const maxEdits = 1000
+
+// Diff returns an edit script that turns a into b, using the Myers
+// algorithm.
+//
+// Each Equal or Delete consumes the next element of a, and each Equal or
+// Insert consumes the next element of b.
+func Diff[T comparable](a, b []T) []Op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]Op, 0, len(a)+len(b))
+	ops = appendOps(ops, Equal, prefix)
+	ops = append(ops, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	ops = appendOps(ops, Equal, suffix)
+	return ops
+}
+
+func myers[T comparable](a, b []T) []Op {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replace(n, m)
+	}
+
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	var trace [][]int
+
+	for d := 0; d <= n+m; d++ {
+		if d > maxEdits {
+			return replace(n, m)
+		}
+
+		// Only diagonals -d through d are needed to retrace this step.
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, n, m)
+			}
+		}
+	}
+
+	return replace(n, m)
+}
+
+// backtrack walks the saved states of the search from the end of both
+// sequences back to the start, recovering the edit script.
+func backtrack(trace [][]int, x, y int) []Op {
+	var ops []Op
+
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || k != d && v[d+k-1] < v[d+k+1] {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := v[d+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, Equal)
+			x--
+			y--
+		}
+
+		if x == prevX {
+			ops = append(ops, Insert)
+		} else {
+			ops = append(ops, Delete)
+		}
+
+		x, y = prevX, prevY
+	}
+
+	ops = appendOps(ops, Equal, x)
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	return ops
+}
+
+func replace(n, m int) []Op {
+	ops := make([]Op, 0, n+m)
+	ops = appendOps(ops, Delete, n)
+	return appendOps(ops, Insert, m)
+}
+
+func appendOps(ops []Op, op Op, n int) []Op {
+	for i := 0; i < n; i++ {
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// Unified returns a unified diff of the lines of two strings, or an empty
+// string if they are equal.
+//
+// Changes are grouped into hunks, each starting with an "@@" header giving the
+// line numbers it covers. Up to context unchanged lines are shown around each
+// change, and longer unchanged regions are left out between hunks.
+func Unified(a, b string, context int) string {
+	aLines, bLines := splitLines(a), splitLines(b)
+	ops := Diff(aLines, bLines)
+	aPos, bPos := positions(ops)
+
+	var sb strings.Builder
+	for _, h := range hunks(ops, context) {
+		fmt.Fprintf(
+			&sb,
+			"@@ -%s +%s @@\n",
+			hunkRange(aPos[h.start], aPos[h.end]),
+			hunkRange(bPos[h.start], bPos[h.end]),
+		)
+
+		for i := h.start; i < h.end; i++ {
+			switch ops[i] {
+			case Equal:
+				writeLine(&sb, ' ', aLines[aPos[i]])
+			case Delete:
+				writeLine(&sb, '-', aLines[aPos[i]])
+			case Insert:
+				writeLine(&sb, '+', bLines[bPos[i]])
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// positions returns how many elements of each sequence are consumed before
+// each op of an edit script, and after the last.
+func positions(ops []Op) (aPos, bPos []int) {
+	aPos = make([]int, len(ops)+1)
+	bPos = make([]int, len(ops)+1)
+
+	for i, op := range ops {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if op != Insert {
+			aPos[i+1]++
+		}
+		if op != Delete {
+			bPos[i+1]++
+		}
+	}
+
+	return aPos, bPos
+}
+
+// A hunk is a range of ops shown together in a unified diff.
+type hunk struct {
+	start, end int
+}
+
+// hunks groups the changes of an edit script with up to context unchanged
+// ops around each. Changes separated by fewer than twice as many unchanged
+// ops share a hunk.
+func hunks(ops []Op, context int) []hunk {
+	var out []hunk
+	last := -1
+
+	for i, op := range ops {
+		if op == Equal {
+			continue
+		}
+
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i + context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		if last >= 0 && i-last <= 2*context+1 {
+			out[len(out)-1].end = end
+		} else {
+			out = append(out, hunk{start: start, end: end})
+		}
+		last = i
+	}
+
+	return out
+}
+
+// splitLines splits a string into lines, keeping the line endings so that a
+// missing newline at the end is a difference.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// hunkRange formats the lines from start to end for a hunk header, numbering
+// lines from 1.
+func hunkRange(start, end int) string {
+	switch n := end - start; n {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return strconv.Itoa(start + 1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, n)
+	}
+}
+
+func writeLine(sb *strings.Builder, prefix byte, line string) {
+	sb.WriteByte(prefix)
+	if strings.HasSuffix(line, "\n") {
+		sb.WriteString(line)
+		return
+	}
+
+	sb.WriteString(line)
+	sb.WriteString("\n\\ No newline at end of file\n")
+}
@@ -0,0 +1,193 @@
+package textdiff_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/textdiff"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{name: "empty", a: "", b: "", want: ""},
+		{name: "equal", a: "abc", b: "abc", want: "==="},
+		{name: "insert", a: "", b: "ab", want: "++"},
+		{name: "delete", a: "ab", b: "", want: "--"},
+		{name: "replace", a: "abc", b: "axc", want: "=-+="},
+		{name: "middle", a: "abcabba", b: "cbabac", want: "--=+==-=+"},
+		{name: "prefix and suffix", a: "xaby", b: "xcy", want: "=--+="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			ops := textdiff.Diff([]rune(tt.a), []rune(tt.b))
+			g.Should(be.Equal(render(ops), tt.want))
+			g.Should(be.Equal(apply(ops, tt.a, tt.b), tt.b))
+		})
+	}
+}
+
+func TestDiffLarge(t *testing.T) {
+	g := ghost.New(t)
+
+	a := strings.Repeat("a", 3000)
+	b := strings.Repeat("b", 3000)
+
+	ops := textdiff.Diff([]rune(a), []rune(b))
+	g.Should(be.Equal(render(ops), strings.Repeat("-", 3000)+strings.Repeat("+", 3000)))
+}
+
+func render(ops []textdiff.Op) string {
+	var sb strings.Builder
+	for _, op := range ops {
+		sb.WriteByte("=-+"[op])
+	}
+	return sb.String()
+}
+
+// apply rebuilds b from a using an edit script.
+func apply(ops []textdiff.Op, a, b string) string {
+	ar, br := []rune(a), []rune(b)
+
+	var out []rune
+	var i, j int
+	for _, op := range ops {
+		switch op {
+		case textdiff.Equal:
+			out = append(out, ar[i])
+			i++
+			j++
+		case textdiff.Delete:
+			i++
+		case textdiff.Insert:
+			out = append(out, br[j])
+			j++
+		}
+	}
+	return string(out)
+}
+
+func TestUnified(t *testing.T) {
+	lines := func(from, to int) string {
+		var sb strings.Builder
+		for i := from; i <= to; i++ {
+			fmt.Fprintf(&sb, "line %d\n", i)
+		}
+		return sb.String()
+	}
+
+	tests := []struct {
+		name    string
+		a       string
+		b       string
+		context int
+		want    string
+	}{
+		{
+			name: "equal",
+			a:    "foo\nbar\n",
+			b:    "foo\nbar\n",
+			want: "",
+		},
+		{
+			name:    "change",
+			a:       "foo\nbar\nbaz\n",
+			b:       "foo\nqux\nbaz\n",
+			context: 3,
+			want: `@@ -1,3 +1,3 @@
+ foo
+-bar
++qux
+ baz
+`,
+		},
+		{
+			name:    "empty",
+			a:       "",
+			b:       "foo\n",
+			context: 3,
+			want: `@@ -0,0 +1 @@
++foo
+`,
+		},
+		{
+			name:    "missing newline",
+			a:       "foo\nbar\n",
+			b:       "foo\nbar",
+			context: 3,
+			want: `@@ -1,2 +1,2 @@
+ foo
+-bar
++bar
+\ No newline at end of file
+`,
+		},
+		{
+			name: "separate hunks",
+			a:    lines(1, 20),
+			b: strings.NewReplacer("line 3\n", "", "line 17", "line 17!").
+				Replace(lines(1, 20)),
+			context: 2,
+			want: `@@ -1,5 +1,4 @@
+ line 1
+ line 2
+-line 3
+ line 4
+ line 5
+@@ -15,5 +14,5 @@
+ line 15
+ line 16
+-line 17
++line 17!
+ line 18
+ line 19
+`,
+		},
+		{
+			name: "merged hunks",
+			a:    lines(1, 8),
+			b: strings.NewReplacer("line 2\n", "", "line 7", "line 7!").
+				Replace(lines(1, 8)),
+			context: 2,
+			want: `@@ -1,8 +1,7 @@
+ line 1
+-line 2
+ line 3
+ line 4
+ line 5
+ line 6
+-line 7
++line 7!
+ line 8
+`,
+		},
+		{
+			name:    "no context",
+			a:       lines(1, 3),
+			b:       strings.Replace(lines(1, 3), "line 2", "line 2!", 1),
+			context: 0,
+			want: `@@ -2 +2 @@
+-line 2
++line 2!
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := ghost.New(t)
+
+			g.Should(be.Equal(textdiff.Unified(tt.a, tt.b, tt.context), tt.want))
+		})
+	}
+}
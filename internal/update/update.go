@@ -0,0 +1,26 @@
+// Package update controls whether assertions should rewrite expected values
+// instead of comparing against them.
+package update
+
+import (
+	"flag"
+	"os"
+	"strconv"
+)
+
+var flagUpdate = flag.Bool(
+	"ghost.update",
+	false,
+	"update golden files and snapshots with the values under test",
+)
+
+// Enabled returns whether updates were requested, either with the
+// -ghost.update flag or by setting GHOST_UPDATE to a true value.
+func Enabled() bool {
+	if *flagUpdate {
+		return true
+	}
+
+	ok, _ := strconv.ParseBool(os.Getenv("GHOST_UPDATE"))
+	return ok
+}
@@ -0,0 +1,166 @@
+package ghost
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/rliebz/ghost/internal/color"
+	"github.com/rliebz/ghost/internal/textdiff"
+)
+
+// A Reporter outputs the results of failed checks.
+type Reporter interface {
+	// Report outputs a failed result for a test.
+	Report(t T, result Result)
+}
+
+// ReporterFunc is an adapter to allow the use of ordinary functions as a
+// [Reporter].
+type ReporterFunc func(t T, result Result)
+
+// Report calls f(t, result).
+func (f ReporterFunc) Report(t T, result Result) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	f(t, result)
+}
+
+// TextReporter reports failures by logging their messages to the test.
+//
+// This is the default [Reporter].
+type TextReporter struct {
+	// SideBySide shows the want and got lines of diffs in two columns instead
+	// of one after the other. Diffs are also shown side by side if the
+	// GHOST_DIFF environment variable is set to "side-by-side".
+	SideBySide bool
+
+	// Width is the number of columns side-by-side diffs are fit within. If it
+	// is not set, the COLUMNS environment variable is used, or 120.
+	Width int
+}
+
+// Report logs the message of a result.
+func (r TextReporter) Report(t T, result Result) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	message := result.Message
+	if r.SideBySide || os.Getenv("GHOST_DIFF") == "side-by-side" {
+		message = textdiff.SideBySide(message, r.width())
+	}
+
+	t.Log(message)
+}
+
+// defaultWidth is the width of side-by-side diffs if the terminal width is not
+// known.
+const defaultWidth = 120
+
+func (r TextReporter) width() int {
+	if r.Width > 0 {
+		return r.Width
+	}
+
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+
+	return defaultWidth
+}
+
+// JSONReporter reports failures as JSON lines, writing one JSON object per
+// failure.
+type JSONReporter struct {
+	m sync.Mutex
+	w io.Writer
+}
+
+// NewJSONReporter creates a [JSONReporter] that writes to w.
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{w: w}
+}
+
+// Report writes a result as a single line of JSON.
+//
+// If the result cannot be written, the error is logged to the test.
+func (r *JSONReporter) Report(t T, result Result) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	data, err := json.Marshal(newJSONResult(testName(t), result))
+	if err != nil {
+		t.Log(fmt.Sprintf("ghost: failed to encode result: %s", err))
+		return
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if _, err := r.w.Write(append(data, '\n')); err != nil {
+		t.Log(fmt.Sprintf("ghost: failed to write result: %s", err))
+	}
+}
+
+// jsonResult is the JSON representation of a [Result].
+type jsonResult struct {
+	Test      string       `json:"test,omitempty"`
+	Ok        bool         `json:"ok"`
+	Assertion string       `json:"assertion,omitempty"`
+	Args      []string     `json:"args,omitempty"`
+	Location  string       `json:"location,omitempty"`
+	Message   string       `json:"message"`
+	Got       string       `json:"got,omitempty"`
+	Want      string       `json:"want,omitempty"`
+	Children  []jsonResult `json:"children,omitempty"`
+}
+
+func newJSONResult(test string, result Result) jsonResult {
+	out := jsonResult{
+		Test:    test,
+		Ok:      result.Ok,
+		Message: color.Plain(result.Message),
+	}
+
+	d := result.Details
+	if d == nil {
+		return out
+	}
+
+	out.Assertion = d.Name
+	out.Args = d.Args
+
+	if !d.Location.IsZero() {
+		out.Location = fmt.Sprintf("%s:%d", d.Location.File, d.Location.Line)
+	}
+
+	if d.Got != nil {
+		out.Got = fmt.Sprint(d.Got)
+	}
+
+	if d.Want != nil {
+		out.Want = fmt.Sprint(d.Want)
+	}
+
+	for _, child := range d.Children {
+		out.Children = append(out.Children, newJSONResult("", child))
+	}
+
+	return out
+}
+
+// testName returns the name of a test, if available.
+func testName(t T) string {
+	if n, ok := t.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+
+	return ""
+}
@@ -0,0 +1,144 @@
+package ghost_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rliebz/ghost"
+	"github.com/rliebz/ghost/be"
+	"github.com/rliebz/ghost/internal/color"
+)
+
+func TestWithReporter(t *testing.T) {
+	g := ghost.New(t)
+
+	var reported []ghost.Result
+	reporter := ghost.ReporterFunc(func(_ ghost.T, result ghost.Result) {
+		reported = append(reported, result)
+	})
+
+	mockT := newMockT()
+	testG := ghost.New(mockT, ghost.WithReporter(reporter))
+
+	testG.Should(be.Equal(1, 2))
+	testG.Should(be.Equal(1, 1))
+
+	g.Should(be.SliceLen(mockT.logCalls, 0))
+	g.Should(be.SliceLen(mockT.failCalls, 1))
+
+	if g.Should(be.SliceLen(reported, 1)) {
+		g.Should(be.Equal(reported[0].Message, "1 != 2\ngot:  1\nwant: 2\n"))
+		g.Must(be.Not(be.Nil(reported[0].Details)))
+		g.Should(be.Equal(reported[0].Details.Name, "be.Equal"))
+		g.Should(be.StringMatching(reported[0].Details.Location.String(), `^report_test.go:\d+$`))
+	}
+}
+
+func TestTextReporter(t *testing.T) {
+	t.Run("color", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		msg := color.ANSIRed + "some message" + color.ANSIReset
+
+		ghost.TextReporter{}.Report(mockT, ghost.Result{Message: msg})
+
+		g.Should(be.DeepEqual(mockT.logCalls, [][]any{{msg}}))
+	})
+
+	t.Run("side by side", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		msg := `got != want
+diff (-want +got):
+@@ -1,2 +1,2 @@
+ name: web
+-port: 80
++port: 8080`
+
+		reporter := ghost.TextReporter{SideBySide: true, Width: 31}
+		reporter.Report(mockT, ghost.Result{Message: msg})
+
+		g.Should(be.DeepEqual(mockT.logCalls, [][]any{{`got != want
+diff (-want +got):
+@@ -1,2 +1,2 @@
+ name: web     |  name: web
+-port: 80      | +port: 8080`}}))
+	})
+
+	t.Run("side by side in collect", func(t *testing.T) {
+		g := ghost.New(t)
+
+		mockT := newMockT()
+		reporter := ghost.TextReporter{SideBySide: true, Width: 25}
+		testG := ghost.New(mockT, ghost.WithReporter(reporter))
+
+		testG.Collect(func(g ghost.Ghost) {
+			g.Should(ghost.Result{Message: "diff (-want +got):\n-foo\n+bar"})
+		})
+
+		g.Must(be.SliceLen(mockT.logCalls, 1))
+		g.Should(be.StringContaining(
+			mockT.logCalls[0][0].(string),
+			"\tdiff (-want +got):\n\t-foo      | +bar",
+		))
+	})
+
+	t.Run("side by side from environment", func(t *testing.T) {
+		g := ghost.New(t)
+
+		t.Setenv("GHOST_DIFF", "side-by-side")
+		t.Setenv("COLUMNS", "21")
+
+		mockT := newMockT()
+		msg := "diff (-want +got):\n-foo\n+bar"
+
+		ghost.TextReporter{}.Report(mockT, ghost.Result{Message: msg})
+
+		g.Should(be.DeepEqual(mockT.logCalls, [][]any{{"diff (-want +got):\n-foo      | +bar"}}))
+	})
+}
+
+func TestJSONReporter(t *testing.T) {
+	g := ghost.New(t)
+
+	var buf bytes.Buffer
+	reporter := ghost.NewJSONReporter(&buf)
+
+	mockT := newMockT()
+	testG := ghost.New(mockT, ghost.WithReporter(reporter))
+
+	got := 1
+	testG.Should(be.Equal(got, 2))
+	testG.Should(ghost.Result{Ok: false, Message: "custom"})
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	g.Must(be.SliceLen(lines, 2))
+
+	type record struct {
+		Assertion string
+		Args      []string
+		Location  string
+		Message   string
+		Got       string
+		Want      string
+	}
+
+	var first record
+	g.NoError(json.Unmarshal(lines[0], &first))
+	g.Should(be.Equal(first.Assertion, "be.Equal"))
+	g.Should(be.DeepEqual(first.Args, []string{"got", "2"}))
+	g.Should(be.Equal(first.Got, "1"))
+	g.Should(be.Equal(first.Want, "2"))
+	g.Should(be.Equal(first.Message, "got != 2\ngot:  1\nwant: 2\n"))
+
+	var second record
+	g.NoError(json.Unmarshal(lines[1], &second))
+	g.Should(be.Equal(second.Message, "custom"))
+	g.Should(be.Zero(second.Assertion))
+	g.Should(be.StringMatching(second.Location, `report_test.go:\d+$`))
+
+	g.Should(be.SliceLen(mockT.logCalls, 0))
+}